@@ -330,6 +330,50 @@ func BenchmarkUtilityFunctions(b *testing.B) {
 	})
 }
 
+// BenchmarkQuery compares reading many parameters with the package-level
+// functions, which parse the query on every call, and with a Query,
+// which parses it once.
+func BenchmarkQuery(b *testing.B) {
+	u := mustParseURL("http://example.com?limit=20&offset=40&sort=name" +
+		"&order=asc&active=true&staff=false&min=1.5&max=9.5&ids=1,2,3" +
+		"&tags=a,b&q=search&page=2")
+
+	b.Run("Package", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = ParseInt(u, "limit", 20, 1, 100)
+			_ = ParseInt(u, "offset")
+			_ = ParseString(u, "sort", "id", "name")
+			_ = ParseString(u, "order", "asc", "desc")
+			_ = ParseBool(u, "active")
+			_ = ParseBool(u, "staff")
+			_ = ParseFloat(u, "min")
+			_ = ParseFloat(u, "max")
+			_ = ParseIntSlice(u, "ids")
+			_ = ParseStringSlice(u, "tags")
+			_ = ParseString(u, "q")
+			_ = ParseInt(u, "page", 1)
+		}
+	})
+
+	b.Run("Query", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q := New(u)
+			_ = q.Int("limit", 20, 1, 100)
+			_ = q.Int("offset")
+			_ = q.String("sort", "id", "name")
+			_ = q.String("order", "asc", "desc")
+			_ = q.Bool("active")
+			_ = q.Bool("staff")
+			_ = q.Float("min")
+			_ = q.Float("max")
+			_ = q.IntSlice("ids")
+			_ = q.StringSlice("tags")
+			_ = q.String("q")
+			_ = q.Int("page", 1)
+		}
+	})
+}
+
 // Helper function to parse URLs
 func mustParseURL(s string) *url.URL {
 	u, err := url.Parse(s)
@@ -0,0 +1,361 @@
+package qp
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Bind parses the query parameters of the given URL into the struct
+// pointed to by dst.
+//
+// Every exported field of the struct is read from the query parameter
+// named in its qp tag, or from the parameter with the field name when the
+// tag is absent. Fields tagged with `qp:"-"` are skipped and embedded
+// structs are bound as if their fields belonged to the outer struct.
+//
+// The tag accepts the following options after the name:
+//
+//   - default=V: the value used if the parameter is absent, empty or
+//     invalid (for slices, a space-separated list of values);
+//   - min=V, max=V: the valid range for numbers and durations, either
+//     bound may be omitted;
+//   - exclusivemin=V, exclusivemax=V: the same bounds, exclusive;
+//   - multipleof=V: the step of numbers and durations;
+//   - oneof=V1 V2: a space-separated list of valid values; they are
+//     valid in addition to the range if any, otherwise they are the only
+//     valid values;
+//   - minitems=N, maxitems=N, maxtotallength=N, unique: the constraints
+//     of a slice as a whole, see MinItems, MaxItems, MaxTotalLength and
+//     Unique;
+//   - split=auto|each|none, separator=S, quoted: how the values of
+//     a slice are split, see Split, Separator and Quoted;
+//   - aliases=A:V B:W, ignorecase: alternative spellings of values and
+//     case-insensitive matching, see Aliases and IgnoreCase;
+//   - minlength=N, maxlength=N, pattern=RE, trim: the string constraints,
+//     see MinLength, MaxLength, Pattern and Trim; the pattern cannot
+//     contain a comma;
+//   - charset=C1 C2: the allowed characters, see Charset, as
+//     a space-separated list of Unicode categories and scripts (e.g. L,
+//     Nd or Latin) and of other characters (e.g. -_);
+//   - nfc: the NFC normalization, see NFC;
+//   - required: the parameter must be present and not empty, otherwise
+//     the error wraps ErrRequired or ErrEmpty;
+//   - omitdefault: ignored by Bind, see Encode.
+//
+// The options have the same meaning as the options of the same name,
+// e.g. Default or ExclusiveMin, for slices the constraints of values
+// apply to every element.
+//
+// Supported field types are integers of any width, float32, float64,
+// string and bool (including named types based on them), time.Duration,
+// time.Time, their slices, and pointers to the scalar types. Values are
+// parsed with the same rules as ParseInteger, ParseFloat32, ParseFloat,
+// ParseString, ParseBool, ParseDuration and ParseTime (with
+// DefaultTimeLayouts in UTC), slices as with the *Slice parsers. Types
+// registered with RegisterParser or RegisterEnum are parsed with the
+// registered parser, types that implement encoding.TextUnmarshaler (e.g.
+// netip.Addr or *big.Int) with their UnmarshalText method; their slices
+// are supported too. Pointer fields behave like the Pull methods: they
+// are set to nil if the parameter is absent.
+//
+// All fields are bound even if some of them are invalid. The returned
+// error joins the errors of all invalid fields. A query that exceeds
+// the default limits, see Limits, is rejected with ErrTooLarge before
+// any field is bound.
+//
+// Example Usage:
+//
+//	type Filter struct {
+//	    Age    int      `qp:"age,default=18,min=18,max=65"`
+//	    Role   string   `qp:"role,default=user,oneof=user admin"`
+//	    IDs    []int    `qp:"ids"`
+//	    Active *bool    `qp:"active"`
+//	}
+//
+//	var f Filter
+//	if err := qp.Bind(u, &f); err != nil {
+//	    http.Error(w, err.Error(), http.StatusBadRequest)
+//	    return
+//	}
+func Bind(u *url.URL, dst any) error {
+	return bind(New(u), dst)
+}
+
+// BindValues parses the given query values into the struct pointed
+// to by dst. See Bind for details of the supported tags and types.
+func BindValues(values url.Values, dst any) error {
+	return bind(FromValues(values), dst)
+}
+
+// bind binds the query to the struct pointed to by dst. A query that
+// exceeds the limits is rejected before any field is bound.
+func bind(q *Query, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() ||
+		rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dst must be a non-nil pointer to a struct, got %T",
+			dst)
+	} else if err := q.checkQuery(); err != nil {
+		return err
+	}
+
+	return walkStruct(rv.Elem(), func(
+		fv reflect.Value,
+		field reflect.StructField,
+		tag fieldTag,
+	) error {
+		return bindField(q, fv, field, tag)
+	})
+}
+
+// bindField binds a single struct field.
+func bindField(
+	q *Query,
+	fv reflect.Value,
+	field reflect.StructField,
+	tag fieldTag,
+) error {
+	// Pointer fields are nil if the parameter is absent.
+	if fv.Kind() == reflect.Pointer {
+		if parserOf(fv.Type().Elem()) == nil {
+			return unsupportedField(field)
+		} else if !q.Contains(tag.name) {
+			fv.SetZero()
+			if tag.required {
+				return requiredError(tag.name, false)
+			}
+			return nil
+		}
+
+		ptr := reflect.New(fv.Type().Elem())
+		err := bindValue(q, ptr.Elem(), field, tag)
+		fv.Set(ptr)
+		return err
+	}
+
+	return bindValue(q, fv, field, tag)
+}
+
+// bindValue parses the query parameter into the non-pointer value.
+func bindValue(
+	q *Query,
+	fv reflect.Value,
+	field reflect.StructField,
+	tag fieldTag,
+) error {
+	if r := parserOf(fv.Type()); r != nil {
+		return r.bind(q, fv, field, tag)
+	} else if fv.Kind() == reflect.Slice {
+		return bindSlice(q, fv, field, tag)
+	}
+
+	return unsupportedField(field)
+}
+
+// bindSlice binds a slice field.
+func bindSlice(
+	q *Query,
+	fv reflect.Value,
+	field reflect.StructField,
+	tag fieldTag,
+) error {
+	if r := parserOf(fv.Type().Elem()); r != nil {
+		return r.bindSlice(q, fv, field, tag)
+	}
+
+	return unsupportedField(field)
+}
+
+// bindScalar binds a scalar field with the given parser. The value is
+// converted to support named types.
+func bindScalar[T any](
+	q *Query,
+	fv reflect.Value,
+	field reflect.StructField,
+	tag fieldTag,
+	p parser[T],
+) error {
+	s, err := tagSpec(tag, p, false)
+	if err != nil {
+		return invalidTag(field, err)
+	}
+
+	result := scalar(q, tag.name, s, p)
+	if value := reflect.ValueOf(result.Value); value.IsValid() {
+		fv.Set(value.Convert(fv.Type()))
+	} else {
+		fv.SetZero() // no default of a text type
+	}
+
+	return result.Error
+}
+
+// bindSliceOf binds a slice field with the parser of its elements.
+// The value is converted to support named slice and element types.
+func bindSliceOf[T any](
+	q *Query,
+	fv reflect.Value,
+	field reflect.StructField,
+	tag fieldTag,
+	p parser[T],
+) error {
+	s, err := tagSpec(tag, p, true)
+	if err != nil {
+		return invalidTag(field, err)
+	} else if s.defs == nil {
+		s.defs = []T{}
+	}
+
+	result := slice(q, tag.name, s, p)
+	value := settle(result, s.defs)
+
+	dst := reflect.MakeSlice(fv.Type(), len(value), len(value))
+	for i, v := range value {
+		dst.Index(i).Set(reflect.ValueOf(v).Convert(fv.Type().Elem()))
+	}
+	fv.Set(dst)
+
+	return result.Error
+}
+
+// settle returns the parsed value, or the default value if the query
+// parameter is absent, empty or invalid.
+func settle[T any](result *Result[T], def T) T {
+	if result.Empty || result.Error != nil {
+		return def
+	}
+
+	return result.Value
+}
+
+// tagSpec converts the default, min, max, oneof and aliases options of
+// the tag with the parser. For slices, the default is a space-separated
+// list of values and the constraints of values apply to every element.
+func tagSpec[T any](tag fieldTag, p parser[T], slice bool) (spec[T], error) {
+	var (
+		s   spec[T]
+		err error
+	)
+
+	s.required = tag.required
+	s.minExclusive, s.maxExclusive = tag.minExclusive, tag.maxExclusive
+	s.minItems, s.maxItems = tag.minItems, tag.maxItems
+	s.maxLength, s.unique = tag.maxLength, tag.unique
+	s.split, s.sep, s.quoted = tag.split, tag.sep, tag.quoted
+	s.ignoreCase = tag.ignoreCase
+	s.minRunes, s.maxRunes, s.trim = tag.minRunes, tag.maxRunes, tag.trim
+	s.normalize = tag.normalize
+	if tag.hasCharset {
+		s.charset = charset(tag.chars, tag.tables)
+	}
+	if !slice && s.sliceOnly() {
+		return s, errSliceOnly
+	}
+
+	if tag.hasDef {
+		s.hasDef = true
+		if slice {
+			s.defs, err = tagValues(strings.Fields(tag.def), p.parse)
+		} else {
+			s.def, err = p.parse(tag.def)
+		}
+		if err != nil {
+			return s, err
+		}
+	}
+
+	if tag.hasMin {
+		if s.min, err = p.parse(tag.min); err != nil {
+			return s, err
+		}
+		s.hasMin = true
+	}
+
+	if tag.hasMax {
+		if s.max, err = p.parse(tag.max); err != nil {
+			return s, err
+		}
+		s.hasMax = true
+	}
+
+	if tag.hasPattern {
+		if s.pattern, err = regexp.Compile(tag.pattern); err != nil {
+			return s, err
+		}
+	}
+
+	if tag.hasStep {
+		if s.step, err = p.parse(tag.step); err != nil {
+			return s, err
+		}
+		s.hasStep = true
+	}
+
+	if s.oneOf, err = tagValues(tag.oneOf, p.parse); err != nil {
+		return s, err
+	}
+
+	for alias, v := range tag.aliases {
+		if s.aliases == nil {
+			s.aliases = make(map[string]T, len(tag.aliases))
+		}
+		if s.aliases[alias], err = p.parse(v); err != nil {
+			return s, err
+		}
+	}
+
+	return s, s.validate(p)
+}
+
+// tagValues converts a list of tag values with the given function.
+func tagValues[T any](raw []string, conv func(string) (T, error)) ([]T, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	result := make([]T, 0, len(raw))
+	for _, str := range raw {
+		value, err := conv(str)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+
+	return result, nil
+}
+
+// durationType is the type of time.Duration, which is bound and encoded
+// in the duration format instead of as an integer.
+var durationType = reflect.TypeFor[time.Duration]()
+
+// timeType is the type of time.Time, which is bound in the layouts of
+// TimeFormat instead of with UnmarshalText.
+var timeType = reflect.TypeFor[time.Time]()
+
+// isScalar reports whether the kind can be bound to a pointer field.
+func isScalar(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64,
+		reflect.String, reflect.Bool:
+		return true
+	}
+
+	return false
+}
+
+// invalidTag returns the error for an invalid qp tag.
+func invalidTag(field reflect.StructField, err error) error {
+	return fmt.Errorf("invalid qp tag of field %s: %w", field.Name, err)
+}
+
+// unsupportedField returns the error for a field of unsupported type.
+func unsupportedField(field reflect.StructField) error {
+	return fmt.Errorf("unsupported type %s of field %s", field.Type, field.Name)
+}
@@ -0,0 +1,591 @@
+package qp
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/goloop/g"
+)
+
+// bindStatus is a named string type used to test binding of named types.
+type bindStatus string
+
+// bindTarget is the struct used by the Bind tests.
+type bindTarget struct {
+	Age      int        `qp:"age,default=20,min=18,max=65,oneof=70 99"`
+	Price    float64    `qp:"price,min=0"`
+	Role     string     `qp:"role,default=user,oneof=user admin"`
+	Status   bindStatus `qp:"status"`
+	Active   bool       `qp:"active,default=true"`
+	IDs      []int      `qp:"ids,default=1 2"`
+	Weights  []float64  `qp:"weights"`
+	Names    []string   `qp:"names"`
+	Flags    []bool     `qp:"flags"`
+	Limit    *int       `qp:"limit,max=100"`
+	Query    *string    `qp:"q"`
+	Page     int
+	Ignored  int `qp:"-"`
+	internal int
+}
+
+// TestBind tests the Bind function.
+func TestBind(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected bindTarget
+		hasError bool
+	}{
+		{
+			name:  "Defaults",
+			query: "",
+			expected: bindTarget{
+				Age:     20,
+				Role:    "user",
+				Active:  true,
+				IDs:     []int{1, 2},
+				Weights: []float64{},
+				Names:   []string{},
+				Flags:   []bool{},
+			},
+		},
+		{
+			name: "All values",
+			query: "age=30&price=9.5&role=admin&status=open&active=no" +
+				"&ids=3,4&weights=0.5&weights=1.5&names=a,b&flags=on" +
+				"&limit=10&q=go&Page=2&Ignored=1",
+			expected: bindTarget{
+				Age:     30,
+				Price:   9.5,
+				Role:    "admin",
+				Status:  "open",
+				Active:  false,
+				IDs:     []int{3, 4},
+				Weights: []float64{0.5, 1.5},
+				Names:   []string{"a", "b"},
+				Flags:   []bool{true},
+				Limit:   g.Ptr(10),
+				Query:   g.Ptr("go"),
+				Page:    2,
+			},
+		},
+		{
+			name:  "Additional valid value",
+			query: "age=99",
+			expected: bindTarget{
+				Age:     99,
+				Role:    "user",
+				Active:  true,
+				IDs:     []int{1, 2},
+				Weights: []float64{},
+				Names:   []string{},
+				Flags:   []bool{},
+			},
+		},
+		{
+			name:  "Empty pointer value",
+			query: "limit=&q=",
+			expected: bindTarget{
+				Age:     20,
+				Role:    "user",
+				Active:  true,
+				IDs:     []int{1, 2},
+				Weights: []float64{},
+				Names:   []string{},
+				Flags:   []bool{},
+				Limit:   g.Ptr(0),
+				Query:   g.Ptr(""),
+			},
+		},
+		{
+			name:  "Invalid values",
+			query: "age=17&price=-1&role=root&ids=1,x&limit=101",
+			expected: bindTarget{
+				Age:     20,
+				Role:    "user",
+				Active:  true,
+				IDs:     []int{1, 2},
+				Weights: []float64{},
+				Names:   []string{},
+				Flags:   []bool{},
+				Limit:   g.Ptr(0),
+			},
+			hasError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+
+			var got bindTarget
+			err := Bind(u, &got)
+			if (err != nil) != tc.hasError {
+				t.Errorf("Bind() error: got = %v, want error %v",
+					err, tc.hasError)
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Bind(): got = %+v, want %+v", got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestBindValues tests the BindValues function.
+func TestBindValues(t *testing.T) {
+	type embedded struct {
+		Sort string `qp:"sort,default=id"`
+	}
+
+	type target struct {
+		embedded
+		Offset int `qp:"offset,min=0"`
+	}
+
+	values := url.Values{"offset": {"5"}}
+
+	var got target
+	if err := BindValues(values, &got); err != nil {
+		t.Fatalf("BindValues() error: %v", err)
+	}
+
+	expected := target{embedded: embedded{Sort: "id"}, Offset: 5}
+	if got != expected {
+		t.Errorf("BindValues(): got = %+v, want %+v", got, expected)
+	}
+}
+
+// TestBindIntegers tests binding of integers of any width.
+func TestBindIntegers(t *testing.T) {
+	type target struct {
+		Level uint8    `qp:"level,default=50,max=100"`
+		TS    int64    `qp:"ts"`
+		Ports []uint16 `qp:"ports,default=80 443"`
+		Delta *int8    `qp:"delta,min=-10"`
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected target
+		hasError bool
+	}{
+		{
+			name:     "Defaults",
+			query:    "",
+			expected: target{Level: 50, Ports: []uint16{80, 443}},
+		},
+		{
+			name:  "Values",
+			query: "level=100&ts=1700000000123&ports=8080&delta=-10",
+			expected: target{
+				Level: 100,
+				TS:    1700000000123,
+				Ports: []uint16{8080},
+				Delta: g.Ptr(int8(-10)),
+			},
+		},
+		{
+			name:  "Overflow",
+			query: "level=256&ports=1,65536&delta=-11",
+			expected: target{
+				Level: 50,
+				Ports: []uint16{80, 443},
+				Delta: g.Ptr(int8(0)),
+			},
+			hasError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+
+			var got target
+			err := Bind(u, &got)
+			if (err != nil) != tc.hasError {
+				t.Errorf("Bind() error: got = %v, want error %v",
+					err, tc.hasError)
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Bind(): got = %+v, want %+v", got, tc.expected)
+			}
+
+			values, err := Encode(got)
+			if err != nil {
+				t.Fatalf("Encode() error: %v", err)
+			}
+
+			var back target
+			if err := BindValues(values, &back); err != nil ||
+				!reflect.DeepEqual(back, got) {
+				t.Errorf("round trip: got = %+v, %v", back, err)
+			}
+		})
+	}
+}
+
+// TestBindFloat32 tests binding of float32 fields.
+func TestBindFloat32(t *testing.T) {
+	type target struct {
+		Lat   float32   `qp:"lat,min=-90,max=90"`
+		Scale float32   `qp:"scale,default=1.5,oneof=0.5 1.5 3"`
+		Point []float32 `qp:"point"`
+		Zoom  *float32  `qp:"zoom"`
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected target
+		hasError bool
+	}{
+		{
+			name:     "Defaults",
+			query:    "",
+			expected: target{Scale: 1.5, Point: []float32{}},
+		},
+		{
+			name:  "Values",
+			query: "lat=50.45&scale=3&point=30.52,50.45&zoom=0.1",
+			expected: target{
+				Lat:   50.45,
+				Scale: 3,
+				Point: []float32{30.52, 50.45},
+				Zoom:  g.Ptr(float32(0.1)),
+			},
+		},
+		{
+			name:  "Overflow",
+			query: "lat=91&scale=2&point=1,3.4e39&zoom=3.4e39",
+			expected: target{
+				Scale: 1.5,
+				Point: []float32{},
+				Zoom:  g.Ptr(float32(0)),
+			},
+			hasError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+
+			var got target
+			err := Bind(u, &got)
+			if (err != nil) != tc.hasError {
+				t.Errorf("Bind() error: got = %v, want error %v",
+					err, tc.hasError)
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Bind(): got = %+v, want %+v", got, tc.expected)
+			}
+
+			values, err := Encode(got)
+			if err != nil {
+				t.Fatalf("Encode() error: %v", err)
+			}
+
+			var back target
+			if err := BindValues(values, &back); err != nil ||
+				!reflect.DeepEqual(back, got) {
+				t.Errorf("round trip: got = %+v, %v", back, err)
+			}
+		})
+	}
+}
+
+// TestBindDuration tests binding and encoding of duration fields.
+func TestBindDuration(t *testing.T) {
+	type target struct {
+		Window time.Duration   `qp:"window,default=5m,min=1s,max=1h"`
+		Steps  []time.Duration `qp:"steps,default=1m 5m"`
+		TTL    *time.Duration  `qp:"ttl"`
+		Raw    int64           `qp:"raw"`
+		Others []int64         `qp:"others"`
+	}
+
+	u, _ := url.Parse("http://example.com?window=PT30M&steps=1s,P1D" +
+		"&ttl=90s&raw=15&others=1,2")
+
+	var got target
+	if err := Bind(u, &got); err != nil {
+		t.Fatalf("Bind() error: %v", err)
+	}
+
+	expected := target{
+		Window: 30 * time.Minute,
+		Steps:  []time.Duration{time.Second, 24 * time.Hour},
+		TTL:    g.Ptr(90 * time.Second),
+		Raw:    15,
+		Others: []int64{1, 2},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Bind(): got = %+v, want %+v", got, expected)
+	}
+
+	values, err := Encode(got)
+	if err != nil {
+		t.Fatalf("Encode() error: %v", err)
+	}
+
+	if values.Get("window") != "30m0s" || values.Get("ttl") != "1m30s" {
+		t.Errorf("Encode(): got = %v", values)
+	}
+
+	var back target
+	if err := BindValues(values, &back); err != nil ||
+		!reflect.DeepEqual(back, got) {
+		t.Errorf("round trip: got = %+v, %v", back, err)
+	}
+
+	u, _ = url.Parse("http://example.com?window=2h")
+	if err := Bind(u, &got); !errors.Is(err, ErrOutOfRange) ||
+		got.Window != 5*time.Minute {
+		t.Errorf("Bind(): got = %v, %v, want default and error",
+			got.Window, err)
+	}
+}
+
+// TestBindRegistered tests binding of types with registered parsers.
+func TestBindRegistered(t *testing.T) {
+	type target struct {
+		SKU      testSKU      `qp:"sku,default=NONE-0"`
+		SKUs     []testSKU    `qp:"skus"`
+		Currency testCurrency `qp:"cur,default=EUR,oneof=EUR USD"`
+		Version  *testVersion `qp:"v,min=1.0"`
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected target
+		err      error
+	}{
+		{
+			name:  "Absent",
+			query: "",
+			expected: target{
+				SKU:      testSKU{"NONE", 0},
+				SKUs:     []testSKU{},
+				Currency: "EUR",
+			},
+		},
+		{
+			name:  "Valid",
+			query: "sku=A-1&skus=B-2,C-3&cur=usd&v=1.2",
+			expected: target{
+				SKU:      testSKU{"A", 1},
+				SKUs:     []testSKU{{"B", 2}, {"C", 3}},
+				Currency: "USD",
+				Version:  &testVersion{1, 2},
+			},
+		},
+		{
+			name:  "Invalid",
+			query: "sku=A1&cur=gbp&v=0.9",
+			expected: target{
+				SKU:      testSKU{"NONE", 0},
+				SKUs:     []testSKU{},
+				Currency: "EUR",
+				Version:  &testVersion{},
+			},
+			err: ErrNotAllowed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tc.query)
+
+			var dst target
+			err := BindValues(values, &dst)
+			if !errors.Is(err, tc.err) {
+				t.Errorf("BindValues() error: got = %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(dst, tc.expected) {
+				t.Errorf("BindValues(): got = %+v, want %+v", dst,
+					tc.expected)
+			}
+		})
+	}
+}
+
+// TestBindRequired tests the required tag option.
+func TestBindRequired(t *testing.T) {
+	type target struct {
+		ID    int      `qp:"id,required"`
+		Name  *string  `qp:"name,required"`
+		Tags  []string `qp:"tags,required"`
+		Limit int      `qp:"limit,default=20"`
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		errs  []error
+	}{
+		{"Present", "id=1&name=a&tags=x", nil},
+		{"Absent", "", []error{ErrRequired}},
+		{"Empty", "id=&name=&tags=", []error{ErrEmpty}},
+		{"Absent pointer", "id=1&tags=x", []error{ErrRequired}},
+		{"Empty slice", "id=1&name=a&tags=", []error{ErrEmpty}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tc.query)
+
+			var dst target
+			err := BindValues(values, &dst)
+			if tc.errs == nil && err != nil {
+				t.Errorf("BindValues() error: got = %v, want nil", err)
+			}
+
+			for _, e := range tc.errs {
+				if !errors.Is(err, e) {
+					t.Errorf("BindValues() error: got = %v, want %v", err, e)
+				}
+			}
+		})
+	}
+}
+
+// TestBindConstraints tests the exclusive bounds and the steps.
+func TestBindConstraints(t *testing.T) {
+	type target struct {
+		Price float64 `qp:"price,exclusivemin=0"`
+		Limit int     `qp:"limit,default=20,max=100,multipleof=10"`
+		Sizes []int   `qp:"sizes,exclusivemax=10"`
+
+		Tags []string `qp:"tags,minitems=1,maxitems=2,unique"`
+		Role string   `qp:"role,oneof=user admin,aliases=root:admin,ignorecase"`
+
+		User string `qp:"user,minlength=3,maxlength=8,pattern=^[a-z]+$,trim"`
+		Name string `qp:"name,charset=L Nd -_,nfc,maxlength=4"`
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected target
+		errs     []error
+	}{
+		{
+			name: "Valid",
+			query: "price=0.5&limit=30&sizes=1,9&tags=a,b&role=ROOT" +
+				"&user=john+&name=Cafe%CC%81",
+			expected: target{0.5, 30, []int{1, 9}, []string{"a", "b"},
+				"admin", "john", "Caf\u00e9"},
+		},
+		{
+			name: "Invalid",
+			query: "price=0&limit=35&sizes=10&tags=a,a&role=guest" +
+				"&user=John&name=a%2Bb",
+			expected: target{0, 20, []int{}, []string{}, "", "", ""},
+			errs: []error{ErrOutOfRange, ErrNotMultiple,
+				ErrNotUnique, ErrNotAllowed, ErrPattern, ErrCharset},
+		},
+		{
+			name:     "Too many items",
+			query:    "tags=a,b,c&role=User&user=jo",
+			expected: target{0, 20, []int{}, []string{}, "user", "", ""},
+			errs:     []error{ErrItemCount, ErrTooShort},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tc.query)
+
+			var dst target
+			err := BindValues(values, &dst)
+			if tc.errs == nil && err != nil {
+				t.Errorf("BindValues() error: got = %v, want nil", err)
+			}
+
+			for _, e := range tc.errs {
+				if !errors.Is(err, e) {
+					t.Errorf("BindValues() error: got = %v, want %v", err, e)
+				}
+			}
+
+			if !reflect.DeepEqual(dst, tc.expected) {
+				t.Errorf("BindValues(): got = %+v, want %+v", dst,
+					tc.expected)
+			}
+		})
+	}
+}
+
+// TestBindErrors tests the errors of invalid targets and tags.
+func TestBindErrors(t *testing.T) {
+	values := url.Values{"x": {"1"}}
+	tests := []struct {
+		name string
+		dst  any
+	}{
+		{"Nil", nil},
+		{"Not a pointer", struct{}{}},
+		{"Pointer to non-struct", new(int)},
+		{"Unsupported type", &struct{ X complex128 }{}},
+		{"Unsupported pointer", &struct{ X *[]int }{}},
+		{"Unknown option", &struct {
+			X int `qp:"x,foo=1"`
+		}{}},
+		{"Empty charset", &struct {
+			X string `qp:"x,charset="`
+		}{}},
+		{"Invalid default", &struct {
+			X int `qp:"x,default=a"`
+		}{}},
+		{"Invalid slice default", &struct {
+			X []bool `qp:"x,default=a"`
+		}{}},
+		{"Range for string", &struct {
+			X string `qp:"x,min=1"`
+		}{}},
+		{"Invalid step", &struct {
+			X int `qp:"x,multipleof=-2"`
+		}{}},
+		{"Invalid item count", &struct {
+			X []int `qp:"x,maxitems=a"`
+		}{}},
+		{"Item count for scalar", &struct {
+			X int `qp:"x,maxitems=1"`
+		}{}},
+		{"Invalid alias", &struct {
+			X string `qp:"x,aliases=a"`
+		}{}},
+		{"Invalid alias value", &struct {
+			X int `qp:"x,aliases=a:b"`
+		}{}},
+		{"Invalid pattern", &struct {
+			X string `qp:"x,pattern=("`
+		}{}},
+		{"Invalid length", &struct {
+			X string `qp:"x,minlength=5,maxlength=2"`
+		}{}},
+		{"Invalid split", &struct {
+			X []int `qp:"x,split=all"`
+		}{}},
+		{"Empty separator", &struct {
+			X []int `qp:"x,separator="`
+		}{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := BindValues(values, tc.dst); err == nil {
+				t.Errorf("BindValues() error: got nil, want error")
+			}
+		})
+	}
+}
@@ -1,10 +1,6 @@
 package qp
 
-import (
-	"fmt"
-	"net/url"
-	"strings"
-)
+import "net/url"
 
 // ParseBool parses a boolean query parameter from the given URL.
 //
@@ -30,39 +26,7 @@ import (
 //	// Default: true
 //	result := ParseBool(u, "enabled", true)
 func ParseBool(u *url.URL, key string, opt ...bool) *Result[bool] {
-	result := &Result[bool]{Key: key, Contains: true}
-	data, ok := u.Query()[key]
-
-	// Default value.
-	if len(opt) >= 1 {
-		result.Default = opt[0]
-		result.Value = result.Default
-	}
-
-	// Check if the query parameter is empty or missing.
-	if !ok {
-		// The query parameter is missing.
-		result.Empty = true
-		result.Contains = false
-		return result
-	} else if data[0] == "" {
-		// The query parameter is empty.
-		result.Empty = true
-		result.Contains = true
-		return result
-	}
-
-	// Convert the result to an integer.
-	raw := strings.ToLower(data[0])
-	value, err := parseBoolValue(raw)
-	if err != nil {
-		msg := "invalid value for key %s: %s"
-		result.Error = fmt.Errorf(msg, key, data[0])
-		return result
-	}
-
-	result.Value = value
-	return result
+	return New(u).Bool(key, opt...)
 }
 
 // GetBool is the function to parse a boolean query parameter and return
@@ -137,60 +101,7 @@ func PullBool(u *url.URL, key string, opt ...bool) *bool {
 //	    fmt.Println("Query parameter is absent.")
 //	}
 func ParseBoolSlice(u *url.URL, key string, opt ...[]bool) *Result[[]bool] {
-	result := &Result[[]bool]{Key: key, Contains: true}
-	data, ok := u.Query()[key]
-
-	// Default value.
-	result.Default = []bool{}
-	result.Value = result.Default
-	if len(opt) >= 1 {
-		result.Default = opt[0]
-		result.Value = result.Default
-	}
-
-	// Check if the query parameter is empty or missing.
-	if !ok {
-		result.Empty = true
-		result.Contains = false
-		return result
-	} else if data[0] == "" {
-		result.Empty = true
-		result.Contains = true
-		return result
-	}
-
-	// An array can be specified as a single string "?flags=true,false,yes,no"
-	// or as multiple values "?flags=true&flags=false&flags=yes&flags=no".
-	if len(data) > 1 {
-		// Multiple values.
-		result.Value = make([]bool, 0, len(data))
-		for _, str := range data {
-			value, err := parseBoolValue(str)
-			if err != nil {
-				msg := "invalid value for key %s: %s"
-				result.Error = fmt.Errorf(msg, key, str)
-				result.Value = []bool{} // not nil
-				return result
-			}
-			result.Value = append(result.Value, value)
-		}
-		return result
-	}
-
-	// Single value.
-	result.Value = make([]bool, 0)
-	for _, str := range strings.Split(data[0], ",") {
-		value, err := parseBoolValue(str)
-		if err != nil {
-			msg := "invalid value for key %s: %s"
-			result.Error = fmt.Errorf(msg, key, str)
-			result.Value = []bool{} // not nil
-			return result
-		}
-		result.Value = append(result.Value, value)
-	}
-
-	return result
+	return New(u).BoolSlice(key, opt...)
 }
 
 // GetBoolSlice is the function to parse a boolean slice query parameter
@@ -229,3 +140,82 @@ func PullBoolSlice(u *url.URL, key string, opt ...[]bool) []bool {
 
 	return data.Value
 }
+
+// Bool parses a boolean query parameter from the given source with
+// the options, see Option. The source is a *url.URL or a *Query.
+//
+// Example Usage:
+//
+//	result := Bool(u, "active", Default(true))
+func Bool(src Source, key string, opts ...Option) *Result[bool] {
+	p := boolParser
+	return scalar(query(src), key, resolve(newOptions(opts), p, false), p)
+}
+
+// BoolSlice parses a boolean slice query parameter from the given source
+// with the options. Default takes a slice, the other options apply to
+// every element.
+//
+// Example Usage:
+//
+//	result := BoolSlice(u, "flags", Default([]bool{true}))
+func BoolSlice(src Source, key string, opts ...Option) *Result[[]bool] {
+	p := boolParser
+	return slice(query(src), key, resolve(newOptions(opts), p, true), p)
+}
+
+// Bool parses a boolean query parameter.
+// See ParseBool for details.
+func (q *Query) Bool(key string, opt ...bool) *Result[bool] {
+	var s spec[bool]
+	if len(opt) > 0 {
+		s.def, s.hasDef = opt[0], true
+	}
+
+	return scalar(q, key, s, boolParser)
+}
+
+// GetBool returns the boolean query parameter value and a boolean
+// indicating if it is present and valid. See GetBool for details.
+func (q *Query) GetBool(key string, opt ...bool) (bool, bool) {
+	data := q.Bool(key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullBool returns a pointer to the boolean query parameter value
+// or nil if it is absent. See PullBool for details.
+func (q *Query) PullBool(key string, opt ...bool) *bool {
+	data := q.Bool(key, opt...)
+	if !data.Contains {
+		return nil
+	}
+
+	return &data.Value
+}
+
+// BoolSlice parses a boolean slice query parameter.
+// See ParseBoolSlice for details.
+func (q *Query) BoolSlice(key string, opt ...[]bool) *Result[[]bool] {
+	return slice(q, key, legacySliceSpec(opt), boolParser)
+}
+
+// GetBoolSlice returns the boolean slice query parameter value and a boolean
+// indicating if it is present and valid. See GetBoolSlice for details.
+func (q *Query) GetBoolSlice(key string, opt ...[]bool) ([]bool, bool) {
+	data := q.BoolSlice(key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullBoolSlice returns the boolean slice query parameter value or nil
+// if it is absent. See PullBoolSlice for details.
+func (q *Query) PullBoolSlice(key string, opt ...[]bool) []bool {
+	data := q.BoolSlice(key, opt...)
+	if !data.Contains {
+		return nil // not default
+	}
+
+	return data.Value
+}
+
+// boolParser is the parser of boolean values.
+var boolParser = parser[bool]{parse: parseBoolValue, plain: true}
@@ -69,6 +69,18 @@
 //	query := where(isActive, isStaff, isSuperuser)
 //	// Result: WHERE is_active=true, is_staff=false
 //
+// # Query
+//
+// Every package-level function parses the raw query string of the URL.
+// A handler that reads several parameters should parse it once with New
+// (or FromValues) and use the methods of the returned Query, which have
+// the same semantics as the functions without the Parse prefix:
+//
+//	q := qp.New(r.URL)
+//	limit := q.Int("limit", 20, 1, 100)
+//	sort, ok := q.GetString("sort", "id", "id", "name")
+//	ids := q.PullIntSlice("ids")
+//
 // # Integer Parsing
 //
 // Parse a single integer:
@@ -94,6 +106,18 @@
 //	ids, ok := qp.GetIntSlice(u, "ids")
 //	ids = qp.PullIntSlice(u, "ids")
 //
+// # Integers of Any Width
+//
+// The generic integer functions parse any signed or unsigned integer
+// type with the bit size of the type, so overflows are reported as
+// ErrOutOfRange instead of wrapping around:
+//
+//	port := qp.ParseInteger[uint16](u, "port", 1024, 65535)
+//	ts, ok := qp.GetInteger[int64](u, "ts")
+//	ids := qp.PullIntegerSlice[uint64](u, "ids")
+//
+// The generic functions accept a Source: a *url.URL or a *Query.
+//
 // # Float Parsing
 //
 // Parse a single float:
@@ -119,6 +143,52 @@
 //	temps, ok := qp.GetFloatSlice(u, "temps")
 //	temps = qp.PullFloatSlice(u, "temps")
 //
+// The Float32 functions parse with a bit size of 32 and report values
+// that do not fit float32 as ErrOutOfRange:
+//
+//	lat, ok := qp.GetFloat32(u, "lat")
+//	point := qp.PullFloat32Slice(u, "point")
+//
+// # Time Parsing
+//
+// ParseTime, GetTime, PullTime and their slice variants parse dates and
+// times with the given layouts, or with DefaultTimeLayouts (RFC 3339 and
+// date-only) if none are given:
+//
+//	since, ok := qp.GetTime(u, "since")
+//	day := qp.PullTime(u, "day", "02.01.2006")
+//
+// TimeFormat adds a default value, a valid window and the location of
+// values without time zone information:
+//
+//	result := qp.TimeFormat{
+//	    Location: time.Local,
+//	    Min:      time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+//	}.Parse(u, "since")
+//
+// # Unix Timestamps
+//
+// ParseUnix, GetUnix, PullUnix and their slice variants convert integer
+// Unix timestamps to times in UTC. The unit is given explicitly or, with
+// UnixAuto, detected from the magnitude of the value. The optional times
+// specify the default value and the window as for ParseInt:
+//
+//	now := time.Now()
+//	ts, ok := qp.GetUnix(u, "ts", qp.UnixAuto, now, now.Add(-time.Hour))
+//
+// Unix and UnixSlice take the options instead, see Option:
+//
+//	since := qp.Unix(u, "since", qp.UnixMillis, qp.Min(now.Add(-time.Hour)))
+//
+// # Duration Parsing
+//
+// ParseDuration, GetDuration, PullDuration and their slice variants accept
+// Go durations ("15m") and ISO 8601 durations ("PT1H30M", "P1DT2H"). The
+// optional values follow the convention of ParseInt:
+//
+//	// Default: 1s, Range: 1s-1h
+//	window, ok := qp.GetDuration(u, "window", time.Second, time.Hour)
+//
 // # String Parsing
 //
 // Parse a single string:
@@ -169,6 +239,172 @@
 //	flags, ok := qp.GetBoolSlice(u, "flags")
 //	flags = qp.PullBoolSlice(u, "flags")
 //
+// # Options
+//
+// Int, Float, Float32, String, Bool, Duration, Time and their slice
+// variants take options instead of positional values:
+//
+//	age := qp.Int(u, "age", qp.Default(18), qp.Min(18), qp.Max(65))
+//	size := qp.Int(u, "size", qp.Default(10), qp.OneOf(10, 20, 50))
+//	window := qp.Duration(u, "window", qp.Max("1h"))
+//
+// ExclusiveMin, ExclusiveMax and MultipleOf restrict numbers further:
+//
+//	price := qp.Float(u, "price", qp.ExclusiveMin(0), qp.MultipleOf(0.01))
+//
+// For slices the options apply to every element, while MinItems,
+// MaxItems, Unique and MaxTotalLength constrain the slice as a whole:
+//
+//	ids := qp.IntSlice(u, "ids", qp.Min(1), qp.MaxItems(100), qp.Unique())
+//
+// Separator, Split and Quoted control how the values are split into
+// elements, e.g. ?names="Doe, John",Smith with Quoted:
+//
+//	names := qp.StringSlice(u, "names", qp.Quoted())
+//	tags := qp.StringSlice(u, "tags", qp.Separator("|"))
+//
+// IgnoreCase and Aliases make the matching of valid values lenient, the
+// canonical spelling given with OneOf is returned:
+//
+//	status := qp.String(u, "status", qp.OneOf("open", "in_progress"),
+//	    qp.Aliases(map[string]string{"wip": "in_progress"}),
+//	    qp.IgnoreCase())
+//
+// MinLength, MaxLength, Pattern and Charset constrain strings, Trim, NFC
+// and Normalize prepare values of any type before they are checked:
+//
+//	user := qp.String(u, "user", qp.Trim(), qp.MaxLength(32),
+//	    qp.Pattern(`^[a-z][a-z0-9_]*$`))
+//	q := qp.String(u, "q", qp.Trim(), qp.NFC(), qp.MaxLength(100))
+//
+// The option values are converted to the type of the parameter. Options
+// that cannot be applied are reported as ErrInvalidOption. Required makes
+// a parameter mandatory:
+//
+//	id := qp.Int(u, "id", qp.Required()) // ErrRequired or ErrEmpty
+//
+// The OutOfRange option clamps values outside the range, or replaces
+// them with the default, and sets Result.Adjusted instead of an error:
+//
+//	limit := qp.Int(u, "limit", qp.Min(1), qp.Max(100),
+//	    qp.OutOfRange(qp.RangeClamp))
+//
+// # Repeated Parameters
+//
+// Scalar parsers use the first value of a repeated parameter. The
+// Duplicates option and Query.WithDuplicates select the last value or
+// reject the parameter with ErrDuplicate, Result.Count holds the number
+// of values:
+//
+//	q := qp.New(r.URL).WithDuplicates(qp.DuplicateReject)
+//	limit := q.Int("limit", 20, 1, 100)
+//
+// # Input Size Limits
+//
+// Limits caps the length of values, the number of slice elements and
+// keys, and the length of the raw query. The limits are set globally with
+// SetDefaultLimits, per Query with WithLimits or per call with the Limit
+// option, and are checked before the values are split. An exceeded limit
+// is reported as ErrTooLarge:
+//
+//	qp.SetDefaultLimits(qp.Limits{MaxElements: 1000, MaxQueryLength: 8192})
+//	ids := qp.IntSlice(u, "ids", qp.Limit(qp.Limits{MaxElements: 50}))
+//
+// # Generic Parsing
+//
+// Parse, Get and Pull accept any Value type, including named types, and
+// take the same options:
+//
+//	type Status string
+//	status := qp.Parse[Status](u, "status", qp.OneOf("active", "archived"))
+//	ids, ok := qp.Get[[]int64](u, "ids")
+//
+// # Custom Types
+//
+// RegisterParser adds support for a type to Parse, Get, Pull and Bind,
+// including its slices:
+//
+//	qp.RegisterParser(func(s string) (OrderID, error) { ... })
+//	id := qp.Parse[OrderID](u, "order")
+//	ids, ok := qp.Get[[]OrderID](u, "orders")
+//
+// Enums map names to constants, e.g. map[string]Sort{"asc": SortAsc}.
+// An unknown name is reported as ErrNotAllowed with the accepted names,
+// RegisterEnum makes Bind read and Encode write the names:
+//
+//	sort := qp.ParseEnum(u, "sort", sortNames, SortAsc)
+//	statuses, ok := qp.GetEnumSlice(u, "status", statusNames, nil)
+//	name, ok := qp.EnumName(sortNames, SortDesc) // "desc"
+//
+// Types that implement encoding.TextUnmarshaler need no registration,
+// time.Time is parsed like ParseTime:
+//
+//	ip := qp.Parse[netip.Addr](u, "ip", qp.Default("127.0.0.1"))
+//	hosts, ok := qp.Get[[]netip.Addr](u, "hosts")
+//	since := qp.Parse[time.Time](u, "since", qp.Min("2020-01-01"))
+//
+// # Errors
+//
+// Parse errors are of type *ParamError and wrap one of the sentinel
+// errors ErrInvalidSyntax, ErrOutOfRange, ErrNotAllowed, ErrNotMultiple,
+// ErrRequired, ErrEmpty, ErrDuplicate, ErrItemCount, ErrNotUnique,
+// ErrTooShort, ErrTooLong, ErrPattern, ErrCharset or ErrTooLarge. The
+// Index field holds the index of an invalid element of a slice:
+//
+//	result := qp.ParseInt(u, "age", 18, 30)
+//	switch {
+//	case errors.Is(result.Error, qp.ErrOutOfRange):
+//	    // ...
+//	case errors.Is(result.Error, qp.ErrInvalidSyntax):
+//	    // ...
+//	}
+//
+//	var e *qp.ParamError
+//	if errors.As(result.Error, &e) {
+//	    fmt.Println(e.Key, e.Raw, e.Index, e.Constraint)
+//	}
+//
+// # Validation
+//
+// Read several parameters and report the errors of all of them at once:
+//
+//	var limit, offset int
+//	err := qp.Validate(u).
+//	    Int(&limit, "limit", 20, 1, 100).
+//	    Int(&offset, "offset", 0, 0, 10000).
+//	    Err() // errors.Join of all failures
+//
+// # Struct Binding
+//
+// Decode a whole query into a tagged struct:
+//
+//	type Filter struct {
+//	    Age    int      `qp:"age,default=18,min=18,max=65"`
+//	    Role   string   `qp:"role,default=user,oneof=user admin"`
+//	    IDs    []int    `qp:"ids"`
+//	    Active *bool    `qp:"active"`
+//	}
+//
+//	u, _ := url.Parse("http://example.com?age=30&ids=1,2,3")
+//	var f Filter
+//	err := qp.Bind(u, &f)
+//
+// Encode a tagged struct back into query values:
+//
+//	values, err := qp.Encode(f)
+//	link := "/users?" + values.Encode()
+//
+// # Strict Mode
+//
+// A strict Query records the keys read through it and reports the other
+// keys of the query, e.g. typos, as ErrUnknownKey. Tracking parameters
+// can be allowed with patterns:
+//
+//	q := qp.New(r.URL).Strict("utm_*")
+//	limit := q.Int("limit", 20, 1, 100)
+//	err := q.Bind(&f)
+//	err = q.CheckUnknown() // ?limt=10 is reported
+//
 // # Utility Functions
 //
 // Check parameter presence:
@@ -189,7 +425,9 @@
 //
 //   - Boolean parsing supports multiple formats: true/false, yes/no, on/off, 1/0
 //   - Slice parameters can be specified either as comma-separated values
-//     (?ids=1,2,3) or as multiple parameters (?ids=1&ids=2&ids=3)
+//     (?ids=1,2,3) or as multiple parameters (?ids=1&ids=2&ids=3), and
+//     numbers and booleans in a mixed form (?ids=1,2&ids=3); see Split,
+//     Separator and Quoted for other forms
 //   - All numeric parsers support range validation and additional valid values
 //   - String parsers support validation against a list of valid values
 package qp
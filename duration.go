@@ -0,0 +1,325 @@
+package qp
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration parses a duration query parameter from the given URL.
+//
+// The value is accepted in the format of time.ParseDuration (e.g., "15m",
+// "1h30m", "250ms") or as an ISO 8601 duration (e.g., "PT1H30M", "P1DT2H",
+// "P2W"). In ISO 8601 durations a day is 24 hours and a week is 7 days,
+// years and months are not supported since their length varies.
+//
+// The optional durations have the same meaning as for ParseInt: the first
+// one is the default value, the first two specify the range (min and max)
+// and any additional durations are treated as additional valid values.
+//
+// Example Usage:
+//
+//	// Simple call without default, min, max, or others.
+//	result := ParseDuration(u, "ttl")
+//
+//	// Call with default value.
+//	// Default: 5m
+//	result := ParseDuration(u, "window", 5*time.Minute)
+//
+//	// Call with default and min-max.
+//	// Default: 1s
+//	// Range:   1s-1h
+//	result := ParseDuration(u, "window", time.Second, time.Hour)
+//
+//	// Call with default, min-max, and additional valid values.
+//	// Default:    1s
+//	// Range:      1s-1h
+//	// Additional: 24h
+//	result := ParseDuration(u, "window", time.Second, time.Hour, 24*time.Hour)
+func ParseDuration(
+	u *url.URL,
+	key string,
+	opt ...time.Duration,
+) *Result[time.Duration] {
+	return New(u).Duration(key, opt...)
+}
+
+// GetDuration parses a duration query parameter and returns the value and
+// a boolean indicating, true - if a value was passed in query params and
+// successfully parsed.
+//
+// The optional durations have the same meaning as for ParseDuration.
+//
+// Example Usage:
+//
+//	// Default: 1s
+//	// Range:   1s-1h
+//	window, ok := GetDuration(u, "window", time.Second, time.Hour)
+func GetDuration(
+	u *url.URL,
+	key string,
+	opt ...time.Duration,
+) (time.Duration, bool) {
+	data := ParseDuration(u, key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullDuration returns a pointer to the parsed duration query parameter
+// value, or nil if the parameter is absent.
+//
+// If the parameter is specified, but it is empty or invalid, a pointer to
+// the default value is returned. The optional durations have the same
+// meaning as for ParseDuration.
+//
+// Example Usage:
+//
+//	ttl := PullDuration(u, "ttl")
+func PullDuration(
+	u *url.URL,
+	key string,
+	opt ...time.Duration,
+) *time.Duration {
+	data := ParseDuration(u, key, opt...)
+	if !data.Contains {
+		return nil
+	}
+
+	return &data.Value
+}
+
+// ParseDurationSlice parses a duration slice query parameter from the
+// given URL.
+//
+// The function supports query parameters specified as a single string
+// (e.g., "?steps=1m,5m,PT1H") or as multiple values (e.g.,
+// "?steps=1m&steps=5m"). Every element is parsed as for ParseDuration.
+//
+// Example Usage:
+//
+//	result := ParseDurationSlice(u, "steps")
+func ParseDurationSlice(
+	u *url.URL,
+	key string,
+	opt ...[]time.Duration,
+) *Result[[]time.Duration] {
+	return New(u).DurationSlice(key, opt...)
+}
+
+// GetDurationSlice parses a duration slice query parameter and returns
+// the slice of values and a boolean indicating if the value is valid.
+//
+// Example Usage:
+//
+//	steps, ok := GetDurationSlice(u, "steps")
+func GetDurationSlice(
+	u *url.URL,
+	key string,
+	opt ...[]time.Duration,
+) ([]time.Duration, bool) {
+	data := ParseDurationSlice(u, key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullDurationSlice parses a duration slice query parameter and returns
+// the slice of values, or nil if the parameter is absent.
+//
+// Example Usage:
+//
+//	steps := PullDurationSlice(u, "steps")
+func PullDurationSlice(
+	u *url.URL,
+	key string,
+	opt ...[]time.Duration,
+) []time.Duration {
+	data := ParseDurationSlice(u, key, opt...)
+	if !data.Contains {
+		return nil // not default
+	}
+
+	return data.Value
+}
+
+// Duration parses a duration query parameter from the given source with
+// the options, see Option. The source is a *url.URL or a *Query.
+//
+// Example Usage:
+//
+//	// Default: 5m, Range: 1s-1h
+//	result := Duration(u, "window", Default(5*time.Minute), Min(time.Second),
+//	    Max("1h"))
+func Duration(src Source, key string, opts ...Option) *Result[time.Duration] {
+	p := durationParser
+	return scalar(query(src), key, resolve(newOptions(opts), p, false), p)
+}
+
+// DurationSlice parses a duration slice query parameter from the given source
+// with the options. Default takes a slice, the other options apply to
+// every element.
+//
+// Example Usage:
+//
+//	result := DurationSlice(u, "steps", Max(time.Hour))
+func DurationSlice(
+	src Source,
+	key string,
+	opts ...Option,
+) *Result[[]time.Duration] {
+	p := durationParser
+	return slice(query(src), key, resolve(newOptions(opts), p, true), p)
+}
+
+// Duration parses a duration query parameter.
+// See ParseDuration for details.
+func (q *Query) Duration(
+	key string,
+	opt ...time.Duration,
+) *Result[time.Duration] {
+	return scalar(q, key, legacySpec(opt), durationParser)
+}
+
+// GetDuration returns the duration query parameter value and a boolean
+// indicating if it is present and valid. See GetDuration for details.
+func (q *Query) GetDuration(
+	key string,
+	opt ...time.Duration,
+) (time.Duration, bool) {
+	data := q.Duration(key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullDuration returns a pointer to the duration query parameter value
+// or nil if it is absent. See PullDuration for details.
+func (q *Query) PullDuration(
+	key string,
+	opt ...time.Duration,
+) *time.Duration {
+	data := q.Duration(key, opt...)
+	if !data.Contains {
+		return nil
+	}
+
+	return &data.Value
+}
+
+// DurationSlice parses a duration slice query parameter.
+// See ParseDurationSlice for details.
+func (q *Query) DurationSlice(
+	key string,
+	opt ...[]time.Duration,
+) *Result[[]time.Duration] {
+	return slice(q, key, legacySliceSpec(opt), durationParser)
+}
+
+// GetDurationSlice returns the duration slice query parameter value and
+// a boolean indicating if it is present and valid.
+// See GetDurationSlice for details.
+func (q *Query) GetDurationSlice(
+	key string,
+	opt ...[]time.Duration,
+) ([]time.Duration, bool) {
+	data := q.DurationSlice(key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullDurationSlice returns the duration slice query parameter value or
+// nil if it is absent. See PullDurationSlice for details.
+func (q *Query) PullDurationSlice(
+	key string,
+	opt ...[]time.Duration,
+) []time.Duration {
+	data := q.DurationSlice(key, opt...)
+	if !data.Contains {
+		return nil // not default
+	}
+
+	return data.Value
+}
+
+// durationParser is the parser of duration values. The values are not
+// plain, since ISO 8601 durations may contain a decimal comma.
+var durationParser = func() parser[time.Duration] {
+	p := numberParser(parseDuration)
+	p.plain = false
+	return p
+}()
+
+// errISODuration is returned for a malformed ISO 8601 duration.
+var errISODuration = errors.New("invalid ISO 8601 duration")
+
+// isoDurationUnits are the designators of the date and the time parts of
+// an ISO 8601 duration in the required order, and their lengths.
+var isoDurationUnits = [2]struct {
+	designators string
+	lengths     []time.Duration
+}{
+	{"WD", []time.Duration{7 * 24 * time.Hour, 24 * time.Hour}},
+	{"HMS", []time.Duration{time.Hour, time.Minute, time.Second}},
+}
+
+// parseDuration parses a string as a Go or an ISO 8601 duration.
+func parseDuration(str string) (time.Duration, error) {
+	body, negative := str, false
+	if body != "" && (body[0] == '-' || body[0] == '+') {
+		body, negative = body[1:], body[0] == '-'
+	}
+
+	if !strings.HasPrefix(body, "P") {
+		return time.ParseDuration(str)
+	}
+
+	var (
+		result time.Duration
+		part   int // 0 - date part, 1 - time part
+		next   int // index of the next allowed designator
+	)
+
+	body = body[1:]
+	if body == "" || body == "T" || strings.HasSuffix(body, "T") {
+		return 0, errISODuration
+	}
+
+	for body != "" {
+		if body[0] == 'T' && part == 0 {
+			part, next, body = 1, 0, body[1:]
+			continue
+		}
+
+		// The number, optionally with a fraction, followed by the unit.
+		i := strings.IndexFunc(body, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.' && r != ','
+		})
+		if i <= 0 {
+			return 0, errISODuration
+		}
+
+		units := isoDurationUnits[part]
+		j := strings.IndexByte(units.designators[next:], body[i])
+		if j < 0 {
+			return 0, errISODuration
+		}
+		next += j
+
+		n, err := strconv.ParseFloat(strings.Replace(body[:i], ",", ".", 1),
+			64)
+		if err != nil {
+			return 0, errISODuration
+		}
+
+		value := n*float64(units.lengths[next]) + float64(result)
+		if value >= math.MaxInt64 {
+			return 0, strconv.ErrRange
+		}
+
+		result = time.Duration(math.Round(value))
+		body, next = body[i+1:], next+1
+	}
+
+	if negative {
+		result = -result
+	}
+
+	return result, nil
+}
@@ -0,0 +1,168 @@
+package qp
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestParseDuration tests the ParseDuration function.
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		opt      []time.Duration
+		expected time.Duration
+		err      error
+	}{
+		{
+			name:     "Go duration",
+			query:    "window=1h30m",
+			expected: 90 * time.Minute,
+		},
+		{
+			name:     "ISO 8601 time part",
+			query:    "window=PT1H30M",
+			expected: 90 * time.Minute,
+		},
+		{
+			name:     "ISO 8601 date and time parts",
+			query:    "window=P1DT2H",
+			expected: 26 * time.Hour,
+		},
+		{
+			name:     "ISO 8601 weeks",
+			query:    "window=P2W",
+			expected: 14 * 24 * time.Hour,
+		},
+		{
+			name:     "ISO 8601 fraction",
+			query:    "window=PT1.5S",
+			expected: 1500 * time.Millisecond,
+		},
+		{
+			name:     "ISO 8601 negative",
+			query:    "window=-PT15M",
+			expected: -15 * time.Minute,
+		},
+		{
+			name:     "ISO 8601 months are not supported",
+			query:    "window=P1M",
+			opt:      []time.Duration{5 * time.Minute},
+			expected: 5 * time.Minute,
+			err:      ErrInvalidSyntax,
+		},
+		{
+			name:     "ISO 8601 wrong order",
+			query:    "window=PT1S1M",
+			err:      ErrInvalidSyntax,
+			expected: 0,
+		},
+		{
+			name:     "ISO 8601 empty time part",
+			query:    "window=P1DT",
+			err:      ErrInvalidSyntax,
+			expected: 0,
+		},
+		{
+			name:     "ISO 8601 overflow",
+			query:    "window=P99999999W",
+			err:      ErrOutOfRange,
+			expected: 0,
+		},
+		{
+			name:     "Invalid",
+			query:    "window=soon",
+			opt:      []time.Duration{5 * time.Minute},
+			expected: 5 * time.Minute,
+			err:      ErrInvalidSyntax,
+		},
+		{
+			name:     "Default value",
+			query:    "",
+			opt:      []time.Duration{5 * time.Minute},
+			expected: 5 * time.Minute,
+		},
+		{
+			name:     "In range",
+			query:    "window=PT30M",
+			opt:      []time.Duration{time.Second, time.Hour},
+			expected: 30 * time.Minute,
+		},
+		{
+			name:     "Out of range",
+			query:    "window=2h",
+			opt:      []time.Duration{time.Second, time.Hour},
+			expected: time.Second,
+			err:      ErrOutOfRange,
+		},
+		{
+			name:     "Additional value",
+			query:    "window=P1D",
+			opt:      []time.Duration{time.Second, time.Hour, 24 * time.Hour},
+			expected: 24 * time.Hour,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			result := ParseDuration(u, "window", tc.opt...)
+
+			if result.Value != tc.expected {
+				t.Errorf(".Value: got = %v, want %v",
+					result.Value, tc.expected)
+			}
+
+			if !errors.Is(result.Error, tc.err) {
+				t.Errorf(".Error: got = %v, want %v", result.Error, tc.err)
+			}
+
+			value, ok := GetDuration(u, "window", tc.opt...)
+			if value != tc.expected ||
+				ok != (tc.err == nil && tc.query != "") {
+				t.Errorf("GetDuration(): got = %v, %v", value, ok)
+			}
+		})
+	}
+}
+
+// TestDurationError tests the error message of a duration out of range.
+func TestDurationError(t *testing.T) {
+	u, _ := url.Parse("http://example.com?window=2h")
+	err := ParseDuration(u, "window", time.Second, time.Hour).Error
+
+	expected := "value out of range for key window: 2h (range [1s, 1h0m0s])"
+	if err == nil || err.Error() != expected {
+		t.Errorf(".Error: got = %v, want %s", err, expected)
+	}
+}
+
+// TestParseDurationSlice tests the duration slice functions.
+func TestParseDurationSlice(t *testing.T) {
+	u, _ := url.Parse("http://example.com?steps=1m,PT5M&bad=1m&bad=x")
+
+	expected := []time.Duration{time.Minute, 5 * time.Minute}
+	if value, ok := GetDurationSlice(u, "steps"); !ok ||
+		!reflect.DeepEqual(value, expected) {
+		t.Errorf("GetDurationSlice(): got = %v, %v, want %v, true",
+			value, ok, expected)
+	}
+
+	var e *ParamError
+	if err := ParseDurationSlice(u, "bad").Error; !errors.As(err, &e) ||
+		e.Index != 1 {
+		t.Errorf("ParseDurationSlice(): got error = %v, want index 1", err)
+	}
+
+	if value := PullDurationSlice(u, "absent"); value != nil {
+		t.Errorf("PullDurationSlice(): got = %v, want nil", value)
+	}
+
+	if value := PullDuration(u, "steps"); value == nil ||
+		*value != 0 {
+		t.Errorf("PullDuration(): got = %v, want pointer to 0", value)
+	}
+}
@@ -0,0 +1,204 @@
+package qp
+
+import (
+	"cmp"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Encode converts the struct src (or a pointer to it) into query values.
+//
+// It is the reverse of Bind and uses the same qp struct tags: every
+// exported field is written to the query parameter named in its tag, or
+// to the parameter with the field name when the tag is absent. Fields
+// tagged with `qp:"-"` are skipped and embedded structs are encoded as if
+// their fields belonged to the outer struct.
+//
+// Values are written in the form the parsers accept, so the output of
+// Encode binds back to the same values:
+//
+//   - integers of any width, float32, float64, string and bool (and named
+//     types based on them) are written as a single value, floats with the
+//     minimal number of digits that represents them exactly;
+//   - time.Duration is written in the format of time.Duration.String
+//     (e.g., "1h30m0s");
+//   - types registered with RegisterEnum are written as their names;
+//   - types that implement encoding.TextUnmarshaler are written with
+//     their MarshalText method (e.g., time.Time in RFC 3339);
+//   - slices are written as a single comma-separated value
+//     (e.g., "?ids=1,2,3"), or as multiple values
+//     (e.g., "?names=a&names=b,c") if any string element contains
+//     a comma; empty slices are skipped. The separator, split and
+//     quoted tag options are respected: elements are joined with the
+//     separator, with split=none always written as multiple values and
+//     with quoted enclosed in quotes if they contain the separator;
+//   - nil pointers are skipped, other pointers are dereferenced.
+//
+// A slice with a single string element that contains the separator, or
+// any such element with split=each, cannot be represented without the
+// quoted option, since the parsers split it into several elements.
+// Encode returns an error for such a slice.
+//
+// If the tag contains the omitdefault option, a non-pointer field is
+// skipped when its value equals the value Bind would produce for an
+// absent parameter, i.e. the tag default or the zero value.
+//
+// Example Usage:
+//
+//	type Page struct {
+//	    Limit  int     `qp:"limit,default=20,omitdefault"`
+//	    Offset int     `qp:"offset"`
+//	    Sort   *string `qp:"sort"`
+//	}
+//
+//	values, err := qp.Encode(Page{Limit: 20, Offset: 40})
+//	// values.Encode() == "offset=40"
+func Encode(src any) (url.Values, error) {
+	rv := reflect.ValueOf(src)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("src must be a struct or a non-nil pointer "+
+			"to a struct, got %T", src)
+	}
+
+	values := url.Values{}
+	err := walkStruct(rv, func(
+		fv reflect.Value,
+		field reflect.StructField,
+		tag fieldTag,
+	) error {
+		return encodeField(values, fv, field, tag)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// encodeField writes a single struct field to the values.
+func encodeField(
+	values url.Values,
+	fv reflect.Value,
+	field reflect.StructField,
+	tag fieldTag,
+) error {
+	pointer := fv.Kind() == reflect.Pointer
+	if pointer {
+		if !isScalar(fv.Type().Elem().Kind()) && !isText(fv.Type().Elem()) {
+			return unsupportedField(field)
+		} else if fv.IsNil() {
+			return nil
+		}
+		fv = fv.Elem()
+	}
+
+	if tag.omitDefault && !pointer {
+		// The default is the value that Bind sets for an absent parameter,
+		// which is not an error here even if the parameter is required.
+		opt := tag
+		opt.required = false
+		def := reflect.New(fv.Type()).Elem()
+		if err := bindValue(FromValues(nil), def, field, opt); err != nil {
+			return err
+		}
+
+		if reflect.DeepEqual(fv.Interface(), def.Interface()) {
+			return nil
+		}
+	}
+
+	if fv.Kind() != reflect.Slice || isText(fv.Type()) {
+		str, ok := formatValue(fv)
+		if !ok {
+			return unsupportedField(field)
+		}
+
+		values.Set(tag.name, str)
+		return nil
+	}
+
+	if !isScalar(fv.Type().Elem().Kind()) && !isText(fv.Type().Elem()) {
+		return unsupportedField(field)
+	} else if fv.Len() == 0 {
+		return nil
+	}
+
+	sep := cmp.Or(tag.sep, ",")
+	items := make([]string, 0, fv.Len())
+	repeat := tag.split == SplitNone
+	for i := 0; i < fv.Len(); i++ {
+		str, _ := formatValue(fv.Index(i))
+		if tag.quoted {
+			if strings.Contains(str, sep) || strings.HasPrefix(str, `"`) {
+				str = `"` + strings.ReplaceAll(str, `"`, `""`) + `"`
+			}
+		} else if !repeat && strings.Contains(str, sep) {
+			// Repeated values are not split by SplitAuto, unless there
+			// is a single value or the elements are numbers or booleans.
+			if tag.split == SplitEach || fv.Len() == 1 ||
+				splitsAll(fv.Type().Elem()) {
+				return fmt.Errorf("element %q of field %s contains the "+
+					"separator %q, use the quoted tag option", str,
+					field.Name, sep)
+			}
+			repeat = true
+		}
+		items = append(items, str)
+	}
+
+	if repeat {
+		values[tag.name] = items
+	} else {
+		values.Set(tag.name, strings.Join(items, sep))
+	}
+
+	return nil
+}
+
+// splitsAll reports whether Bind splits every value of a slice with the
+// element type, see SplitAuto: the elements are numbers or booleans.
+func splitsAll(typ reflect.Type) bool {
+	if registered(typ) != nil || isText(typ) || typ == durationType {
+		return false
+	}
+
+	return isScalar(typ.Kind()) && typ.Kind() != reflect.String
+}
+
+// formatValue converts a scalar value to its query representation.
+// It returns false if the value type is not supported.
+func formatValue(rv reflect.Value) (string, bool) {
+	if r := registered(rv.Type()); r != nil && r.format != nil {
+		return r.format(rv)
+	} else if isText(rv.Type()) {
+		return formatText(rv)
+	} else if rv.Type() == durationType {
+		return time.Duration(rv.Int()).String(), true
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		bits := rv.Type().Bits()
+		return strconv.FormatFloat(rv.Float(), 'f', -1, bits), true
+	case reflect.String:
+		return rv.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,227 @@
+package qp
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/goloop/g"
+)
+
+// TestEncode tests the Encode function.
+func TestEncode(t *testing.T) {
+	type page struct {
+		Limit  int      `qp:"limit,default=20,omitdefault"`
+		Offset int      `qp:"offset"`
+		Sort   *string  `qp:"sort"`
+		Tags   []string `qp:"tags,default=a b,omitdefault"`
+		Skip   string   `qp:"-"`
+	}
+
+	tests := []struct {
+		name     string
+		src      any
+		expected string
+	}{
+		{
+			name:     "Defaults are skipped",
+			src:      page{Limit: 20, Tags: []string{"a", "b"}},
+			expected: "offset=0",
+		},
+		{
+			name: "All values",
+			src: &page{
+				Limit:  50,
+				Offset: 10,
+				Sort:   g.Ptr("name"),
+				Tags:   []string{"x", "y"},
+				Skip:   "z",
+			},
+			expected: "limit=50&offset=10&sort=name&tags=x%2Cy",
+		},
+		{
+			name:     "Strings with commas",
+			src:      page{Tags: []string{"Doe, John", "Smith"}},
+			expected: "limit=0&offset=0&tags=Doe%2C+John&tags=Smith",
+		},
+		{
+			name: "Required with omitdefault",
+			src: struct {
+				ID int `qp:"id,required,omitdefault"`
+			}{ID: 5},
+			expected: "id=5",
+		},
+		{
+			name: "Required default is skipped",
+			src: struct {
+				ID int `qp:"id,required,omitdefault"`
+			}{},
+			expected: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Encode(tc.src)
+			if err != nil {
+				t.Fatalf("Encode() error: %v", err)
+			}
+
+			if got.Encode() != tc.expected {
+				t.Errorf("Encode(): got = %s, want %s",
+					got.Encode(), tc.expected)
+			}
+		})
+	}
+}
+
+// TestEncodeRoundTrip tests that encoded values bind back
+// to the same struct.
+func TestEncodeRoundTrip(t *testing.T) {
+	src := bindTarget{
+		Age:     99,
+		Price:   0.1,
+		Role:    "admin",
+		Status:  "open",
+		Active:  false,
+		IDs:     []int{3, 4},
+		Weights: []float64{1e-9, 2.5},
+		Names:   []string{"a", "b,c"},
+		Flags:   []bool{true, false},
+		Limit:   g.Ptr(100),
+		Page:    3,
+	}
+
+	values, err := Encode(src)
+	if err != nil {
+		t.Fatalf("Encode() error: %v", err)
+	}
+
+	u, _ := url.Parse("http://example.com?" + values.Encode())
+
+	var got bindTarget
+	if err := Bind(u, &got); err != nil {
+		t.Fatalf("Bind() error: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, src) {
+		t.Errorf("round trip: got = %+v, want %+v", got, src)
+	}
+}
+
+// TestEncodeSplit tests that slices are encoded with the separator,
+// split and quoted tag options and bind back.
+func TestEncodeSplit(t *testing.T) {
+	type target struct {
+		Names  []string `qp:"names,quoted"`
+		Tags   []string `qp:"tags,separator=|"`
+		People []string `qp:"people,split=none"`
+		Sizes  []int    `qp:"sizes,separator=;,split=each"`
+	}
+
+	tests := []struct {
+		name     string
+		src      target
+		expected url.Values
+	}{
+		{
+			name: "Plain",
+			src: target{
+				Names:  []string{"a", "b"},
+				Tags:   []string{"x,y", "z"},
+				People: []string{"Doe, John"},
+				Sizes:  []int{1, 2},
+			},
+			expected: url.Values{
+				"names":  {"a,b"},
+				"tags":   {"x,y|z"},
+				"people": {"Doe, John"},
+				"sizes":  {"1;2"},
+			},
+		},
+		{
+			name: "Quoted",
+			src: target{
+				Names:  []string{"Doe, John", `"hi"`, `a"b`},
+				Tags:   []string{"a|b", "c"},
+				People: []string{"a", "b"},
+				Sizes:  []int{3},
+			},
+			expected: url.Values{
+				"names":  {`"Doe, John","""hi""",a"b`},
+				"tags":   {"a|b", "c"},
+				"people": {"a", "b"},
+				"sizes":  {"3"},
+			},
+		},
+		{
+			name: "Single quoted element",
+			src: target{
+				Names:  []string{"Doe, John"},
+				Tags:   []string{"a", "b"},
+				People: []string{"Doe, John"},
+				Sizes:  []int{1},
+			},
+			expected: url.Values{
+				"names":  {`"Doe, John"`},
+				"tags":   {"a|b"},
+				"people": {"Doe, John"},
+				"sizes":  {"1"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := Encode(tc.src)
+			if err != nil {
+				t.Fatalf("Encode() error: %v", err)
+			}
+
+			if !reflect.DeepEqual(values, tc.expected) {
+				t.Errorf("Encode(): got = %v, want %v", values,
+					tc.expected)
+			}
+
+			var got target
+			if err := BindValues(values, &got); err != nil {
+				t.Fatalf("BindValues() error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.src) {
+				t.Errorf("round trip: got = %+v, want %+v", got, tc.src)
+			}
+		})
+	}
+}
+
+// TestEncodeErrors tests the errors of invalid sources.
+func TestEncodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  any
+	}{
+		{"Nil", nil},
+		{"Nil pointer", (*bindTarget)(nil)},
+		{"Not a struct", 1},
+		{"Unsupported type", struct{ X complex128 }{}},
+		{"Unsupported slice", struct{ X []complex128 }{}},
+		{"Single element with separator", struct{ X []string }{
+			[]string{"Doe, John"},
+		}},
+		{"Split element with separator", struct {
+			X []string `qp:"x,split=each"`
+		}{[]string{"a,b", "c"}}},
+		{"Number with separator", struct {
+			X []float64 `qp:"x,separator=."`
+		}{[]float64{1.5, 2}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Encode(tc.src); err == nil {
+				t.Errorf("Encode() error: got nil, want error")
+			}
+		})
+	}
+}
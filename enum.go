@@ -0,0 +1,252 @@
+package qp
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// ParseEnum parses a query parameter whose values are names mapped to
+// the constants of T, e.g. "?sort=desc" to SortDesc.
+//
+// The names are matched exactly, or case-insensitively with the
+// IgnoreCase option. If the query parameter is absent, empty or invalid,
+// the default value is returned. An unknown name is reported as
+// ErrNotAllowed, the Constraint of the error lists the accepted names.
+// Several names may map to the same value, e.g. "desc" and "descending".
+//
+// The other options have the same meaning as for String: OneOf narrows
+// the valid values (given as names or as values of T), Aliases adds
+// spellings, Required makes the parameter mandatory. A Default option
+// overrides the default value.
+//
+// Example Usage:
+//
+//	type Sort int
+//
+//	const (
+//	    SortAsc Sort = iota
+//	    SortDesc
+//	)
+//
+//	var sortNames = map[string]Sort{"asc": SortAsc, "desc": SortDesc}
+//
+//	// ?sort=desc
+//	// Default: SortAsc
+//	result := qp.ParseEnum(u, "sort", sortNames, SortAsc)
+//	if result.Error != nil {
+//	    // "value not allowed for key sort: up (one of [asc desc])"
+//	}
+func ParseEnum[T comparable](
+	src Source,
+	key string,
+	names map[string]T,
+	def T,
+	opts ...Option,
+) *Result[T] {
+	opts = append([]Option{Default(def)}, opts...)
+	o, p := newOptions(opts), enumParser(names)
+	return scalar(query(src), key, resolve(o, p, false), p)
+}
+
+// GetEnum parses an enum query parameter and returns the value and
+// a boolean indicating, true - if a value was passed in query params and
+// successfully parsed. See ParseEnum for details.
+//
+// Example Usage:
+//
+//	sort, ok := qp.GetEnum(u, "sort", sortNames, SortAsc)
+func GetEnum[T comparable](
+	src Source,
+	key string,
+	names map[string]T,
+	def T,
+	opts ...Option,
+) (T, bool) {
+	data := ParseEnum(src, key, names, def, opts...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullEnum returns a pointer to the parsed enum query parameter value,
+// or nil if the parameter is absent. See ParseEnum for details.
+//
+// Example Usage:
+//
+//	sort := qp.PullEnum(u, "sort", sortNames, SortAsc)
+func PullEnum[T comparable](
+	src Source,
+	key string,
+	names map[string]T,
+	def T,
+	opts ...Option,
+) *T {
+	data := ParseEnum(src, key, names, def, opts...)
+	if !data.Contains {
+		return nil
+	}
+
+	return &data.Value
+}
+
+// ParseEnumSlice parses a slice query parameter of enum names, e.g.
+// "?status=open,closed". Every element is parsed as for ParseEnum and
+// the error of an unknown name holds its index. A nil default is
+// returned as an empty slice.
+//
+// Example Usage:
+//
+//	// ?status=open,closed
+//	result := qp.ParseEnumSlice(u, "status", statusNames, nil,
+//	    qp.Unique())
+func ParseEnumSlice[T comparable](
+	src Source,
+	key string,
+	names map[string]T,
+	def []T,
+	opts ...Option,
+) *Result[[]T] {
+	if def != nil {
+		opts = append([]Option{Default(def)}, opts...)
+	}
+
+	o, p := newOptions(opts), enumParser(names)
+	return slice(query(src), key, resolve(o, p, true), p)
+}
+
+// GetEnumSlice parses an enum slice query parameter and returns the slice
+// of values and a boolean indicating if the value is valid.
+// See ParseEnumSlice for details.
+//
+// Example Usage:
+//
+//	statuses, ok := qp.GetEnumSlice(u, "status", statusNames, nil)
+func GetEnumSlice[T comparable](
+	src Source,
+	key string,
+	names map[string]T,
+	def []T,
+	opts ...Option,
+) ([]T, bool) {
+	data := ParseEnumSlice(src, key, names, def, opts...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullEnumSlice parses an enum slice query parameter and returns the
+// slice of values, or nil if the parameter is absent.
+// See ParseEnumSlice for details.
+//
+// Example Usage:
+//
+//	statuses := qp.PullEnumSlice(u, "status", statusNames, nil)
+func PullEnumSlice[T comparable](
+	src Source,
+	key string,
+	names map[string]T,
+	def []T,
+	opts ...Option,
+) []T {
+	data := ParseEnumSlice(src, key, names, def, opts...)
+	if !data.Contains {
+		return nil // not default
+	}
+
+	return data.Value
+}
+
+// RegisterEnum registers the names of the constants of T, so values of T
+// and []T can be read with Parse, Get, Pull and Bind like with ParseEnum,
+// and are written as names by Encode. The options and tags have the same
+// meaning as for ParseEnum; the default value is given with the Default
+// option or the default tag, as a name.
+//
+// A value that has several names is encoded with the first of them in
+// sorted order, Encode fails for a value that has no name. It is
+// typically done in an init function, RegisterEnum is safe for
+// concurrent use.
+//
+// Example Usage:
+//
+//	func init() {
+//	    qp.RegisterEnum(sortNames)
+//	}
+//
+//	type Page struct {
+//	    Sort Sort `qp:"sort,default=asc"`
+//	}
+//
+//	// ?sort=desc
+//	var page Page
+//	err := qp.Bind(u, &page)          // page.Sort == SortDesc
+//	values, err := qp.Encode(page)    // sort=desc
+//	sort := qp.Parse[Sort](u, "sort") // SortDesc
+func RegisterEnum[T comparable](names map[string]T) {
+	if len(names) == 0 {
+		panic("no enum names")
+	}
+
+	names = maps.Clone(names)
+	register(enumParser(names), func(value T) (string, bool) {
+		return EnumName(names, value)
+	})
+}
+
+// EnumName returns the name of the value, the reverse of the mapping
+// given to ParseEnum. If the value has several names, the first of them
+// in sorted order is returned. It returns false if the value has no
+// name.
+//
+// Example Usage:
+//
+//	name, _ := qp.EnumName(sortNames, SortDesc) // "desc"
+//	link := "/items?sort=" + url.QueryEscape(name)
+func EnumName[T comparable](names map[string]T, value T) (string, bool) {
+	for _, name := range sortedKeys(names) {
+		if names[name] == value {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// enumParser returns the parser of the names of the values of T. The
+// error of an unknown name lists the names, and the values are formatted
+// as their names. With IgnoreCase the names are matched in sorted order.
+func enumParser[T comparable](names map[string]T) parser[T] {
+	sorted := sortedKeys(names)
+	reverse := make(map[T]string, len(names))
+	for _, name := range sorted {
+		if _, ok := reverse[names[name]]; !ok {
+			reverse[names[name]] = name
+		}
+	}
+
+	constraint := "one of [" + strings.Join(sorted, " ") + "]"
+	return parser[T]{
+		parse: func(str string) (T, error) {
+			value, ok := names[str]
+			if !ok {
+				return value, &constraintError{ErrNotAllowed, constraint}
+			}
+
+			return value, nil
+		},
+		format: func(value T) string {
+			if name, ok := reverse[value]; ok {
+				return name
+			}
+
+			return fmt.Sprint(value)
+		},
+		fold: func(str string) (T, bool) {
+			for _, name := range sorted {
+				if strings.EqualFold(name, str) {
+					return names[name], true
+				}
+			}
+
+			var zero T
+			return zero, false
+		},
+	}
+}
@@ -0,0 +1,354 @@
+package qp
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// testStatus is an enum type with a registered name mapping.
+type testStatus int
+
+const (
+	testOpen testStatus = iota + 1
+	testInProgress
+	testClosed
+)
+
+// testStatusNames are the names of the testStatus values, "done" is
+// a second name of testClosed.
+var testStatusNames = map[string]testStatus{
+	"open":        testOpen,
+	"in_progress": testInProgress,
+	"closed":      testClosed,
+	"done":        testClosed,
+}
+
+func init() {
+	RegisterEnum(testStatusNames)
+}
+
+// TestParseEnum tests the ParseEnum function.
+func TestParseEnum(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		opts       []Option
+		expected   testStatus
+		err        error
+		constraint string
+	}{
+		{
+			name:     "Name",
+			query:    "status=in_progress",
+			expected: testInProgress,
+		},
+		{
+			name:     "Second name",
+			query:    "status=done",
+			expected: testClosed,
+		},
+		{
+			name:     "Absent",
+			query:    "",
+			expected: testOpen,
+		},
+		{
+			name:       "Unknown name",
+			query:      "status=bogus",
+			expected:   testOpen,
+			err:        ErrNotAllowed,
+			constraint: "one of [closed done in_progress open]",
+		},
+		{
+			name:       "Exact case",
+			query:      "status=Closed",
+			expected:   testOpen,
+			err:        ErrNotAllowed,
+			constraint: "one of [closed done in_progress open]",
+		},
+		{
+			name:     "Ignore case",
+			query:    "status=Closed",
+			opts:     []Option{IgnoreCase()},
+			expected: testClosed,
+		},
+		{
+			name:  "Alias",
+			query: "status=wip",
+			opts: []Option{
+				Aliases(map[string]string{"wip": "in_progress"}),
+			},
+			expected: testInProgress,
+		},
+		{
+			name:       "Valid names",
+			query:      "status=closed",
+			opts:       []Option{OneOf("open", testInProgress)},
+			expected:   testOpen,
+			err:        ErrNotAllowed,
+			constraint: "one of [open in_progress]",
+		},
+		{
+			name:     "Default option",
+			query:    "status=",
+			opts:     []Option{Default("closed")},
+			expected: testClosed,
+		},
+		{
+			name:     "Required",
+			query:    "",
+			opts:     []Option{Required()},
+			expected: testOpen,
+			err:      ErrRequired,
+		},
+		{
+			name:     "Invalid option",
+			query:    "status=open",
+			opts:     []Option{OneOf("bogus")},
+			expected: testOpen,
+			err:      ErrInvalidOption,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			got := ParseEnum(u, "status", testStatusNames, testOpen,
+				tc.opts...)
+
+			if got.Value != tc.expected {
+				t.Errorf("ParseEnum() .Value: got = %v, want %v",
+					got.Value, tc.expected)
+			}
+
+			if !errors.Is(got.Error, tc.err) {
+				t.Errorf("ParseEnum() .Error: got = %v, want %v",
+					got.Error, tc.err)
+			}
+
+			var e *ParamError
+			if errors.As(got.Error, &e) && e.Constraint != tc.constraint {
+				t.Errorf("ParseEnum() .Constraint: got = %q, want %q",
+					e.Constraint, tc.constraint)
+			}
+		})
+	}
+}
+
+// TestParseEnumError tests the message of an unknown name.
+func TestParseEnumError(t *testing.T) {
+	u, _ := url.Parse("http://example.com?sort=up")
+	names := map[string]int{"asc": 1, "desc": -1}
+
+	err := ParseEnum(u, "sort", names, 1).Error
+	expected := "value not allowed for key sort: up (one of [asc desc])"
+	if err == nil || err.Error() != expected {
+		t.Errorf("ParseEnum() .Error: got = %v, want %s", err, expected)
+	}
+}
+
+// TestGetEnum tests the GetEnum and PullEnum functions.
+func TestGetEnum(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected testStatus
+		ok       bool
+		pulled   bool
+	}{
+		{"Valid", "status=closed", testClosed, true, true},
+		{"Invalid", "status=bogus", testOpen, false, true},
+		{"Empty", "status=", testOpen, false, true},
+		{"Absent", "", testOpen, false, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			got, ok := GetEnum(u, "status", testStatusNames, testOpen)
+			if got != tc.expected || ok != tc.ok {
+				t.Errorf("GetEnum(): got = %v, %v, want %v, %v", got, ok,
+					tc.expected, tc.ok)
+			}
+
+			ptr := PullEnum(u, "status", testStatusNames, testOpen)
+			if (ptr != nil) != tc.pulled ||
+				ptr != nil && *ptr != tc.expected {
+				t.Errorf("PullEnum(): got = %v, want %v", ptr, tc.expected)
+			}
+		})
+	}
+}
+
+// TestParseEnumSlice tests the enum slice functions.
+func TestParseEnumSlice(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		def      []testStatus
+		opts     []Option
+		expected []testStatus
+		err      error
+		index    int
+	}{
+		{
+			name:     "Single value",
+			query:    "status=open,done",
+			expected: []testStatus{testOpen, testClosed},
+		},
+		{
+			name:     "Multiple values",
+			query:    "status=open&status=in_progress",
+			expected: []testStatus{testOpen, testInProgress},
+		},
+		{
+			name:     "Absent",
+			query:    "",
+			expected: []testStatus{},
+		},
+		{
+			name:     "Absent with default",
+			query:    "",
+			def:      []testStatus{testOpen},
+			expected: []testStatus{testOpen},
+		},
+		{
+			name:     "Unknown name",
+			query:    "status=open,bogus",
+			expected: []testStatus{},
+			err:      ErrNotAllowed,
+			index:    1,
+		},
+		{
+			name:     "Ignore case",
+			query:    "status=OPEN,Done",
+			opts:     []Option{IgnoreCase()},
+			expected: []testStatus{testOpen, testClosed},
+		},
+		{
+			name:     "Unique",
+			query:    "status=closed,done",
+			opts:     []Option{Unique()},
+			expected: []testStatus{},
+			err:      ErrNotUnique,
+			index:    1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			got := ParseEnumSlice(u, "status", testStatusNames, tc.def,
+				tc.opts...)
+
+			if !reflect.DeepEqual(got.Value, tc.expected) {
+				t.Errorf("ParseEnumSlice() .Value: got = %v, want %v",
+					got.Value, tc.expected)
+			}
+
+			if !errors.Is(got.Error, tc.err) {
+				t.Errorf("ParseEnumSlice() .Error: got = %v, want %v",
+					got.Error, tc.err)
+			}
+
+			var e *ParamError
+			if errors.As(got.Error, &e) && e.Index != tc.index {
+				t.Errorf("ParseEnumSlice() .Index: got = %d, want %d",
+					e.Index, tc.index)
+			}
+
+			values, ok := GetEnumSlice(u, "status", testStatusNames,
+				tc.def, tc.opts...)
+			if !reflect.DeepEqual(values, got.Value) ||
+				ok != (got.Contains && got.Error == nil) {
+				t.Errorf("GetEnumSlice(): got = %v, %v", values, ok)
+			}
+
+			pulled := PullEnumSlice(u, "status", testStatusNames, tc.def,
+				tc.opts...)
+			if (pulled != nil) != got.Contains {
+				t.Errorf("PullEnumSlice(): got = %v", pulled)
+			}
+		})
+	}
+}
+
+// TestEnumName tests the EnumName function.
+func TestEnumName(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    testStatus
+		expected string
+		ok       bool
+	}{
+		{"Single name", testOpen, "open", true},
+		{"First of names", testClosed, "closed", true},
+		{"No name", testStatus(0), "", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := EnumName(testStatusNames, tc.value)
+			if got != tc.expected || ok != tc.ok {
+				t.Errorf("EnumName(): got = %q, %v, want %q, %v", got, ok,
+					tc.expected, tc.ok)
+			}
+		})
+	}
+}
+
+// TestRegisterEnum tests the registered enum with the generic
+// functions, Bind and Encode.
+func TestRegisterEnum(t *testing.T) {
+	type target struct {
+		Status  testStatus   `qp:"status,default=open"`
+		Filter  []testStatus `qp:"filter,ignorecase"`
+		Pointer *testStatus  `qp:"ptr"`
+	}
+
+	u, _ := url.Parse("http://example.com?status=done&filter=Open,WIP")
+	if got := Parse[testStatus](u, "status"); got.Value != testClosed {
+		t.Errorf("Parse(): got = %v, want %v", got.Value, testClosed)
+	}
+
+	var dst target
+	err := Bind(u, &dst)
+	if !errors.Is(err, ErrNotAllowed) {
+		t.Errorf("Bind() error: got = %v, want %v", err, ErrNotAllowed)
+	}
+
+	u, _ = url.Parse("http://example.com?status=done&filter=Open,CLOSED" +
+		"&ptr=in_progress")
+	if err := Bind(u, &dst); err != nil {
+		t.Fatalf("Bind() error: %v", err)
+	}
+
+	expected := target{
+		Status:  testClosed,
+		Filter:  []testStatus{testOpen, testClosed},
+		Pointer: &[]testStatus{testInProgress}[0],
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("Bind(): got = %+v, want %+v", dst, expected)
+	}
+
+	values, err := Encode(dst)
+	if err != nil {
+		t.Fatalf("Encode() error: %v", err)
+	}
+
+	want := url.Values{
+		"status": {"closed"},
+		"filter": {"open,closed"},
+		"ptr":    {"in_progress"},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("Encode(): got = %v, want %v", values, want)
+	}
+
+	if _, err := Encode(target{Status: 42}); err == nil {
+		t.Errorf("Encode() error: got nil, want error")
+	}
+}
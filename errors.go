@@ -0,0 +1,195 @@
+package qp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	// ErrInvalidSyntax is returned when the value of a query parameter
+	// cannot be parsed into the requested type.
+	ErrInvalidSyntax = errors.New("invalid value")
+
+	// ErrOutOfRange is returned when the value of a query parameter is
+	// outside the valid range, either of the type itself or the range
+	// given by the caller.
+	ErrOutOfRange = errors.New("value out of range")
+
+	// ErrNotAllowed is returned when the value of a query parameter is
+	// not in the list of valid values.
+	ErrNotAllowed = errors.New("value not allowed")
+
+	// ErrNotMultiple is returned when the value of a query parameter is
+	// not a multiple of the step given with MultipleOf.
+	ErrNotMultiple = errors.New("value not a multiple")
+
+	// ErrInvalidOption is returned when the options of a query parameter
+	// are invalid, e.g. Min for a boolean parameter or a default value
+	// that cannot be converted to the type of the parameter.
+	ErrInvalidOption = errors.New("invalid option")
+
+	// ErrRequired is returned when a required query parameter is absent.
+	ErrRequired = errors.New("required parameter is missing")
+
+	// ErrEmpty is returned when a required query parameter is present
+	// but has an empty value, e.g. "?id=".
+	ErrEmpty = errors.New("required parameter is empty")
+
+	// ErrDuplicate is returned when a scalar query parameter is repeated
+	// and the DuplicateReject policy is in effect.
+	ErrDuplicate = errors.New("duplicate parameter")
+
+	// ErrItemCount is returned when a slice query parameter has fewer
+	// items than MinItems or more than MaxItems.
+	ErrItemCount = errors.New("invalid number of items")
+
+	// ErrNotUnique is returned when an element of a slice query parameter
+	// repeats a previous element and Unique is set.
+	ErrNotUnique = errors.New("value not unique")
+
+	// ErrTooLong is returned when the value of a query parameter is
+	// longer than MaxLength, or the elements of a slice are longer in
+	// total than MaxTotalLength.
+	ErrTooLong = errors.New("value too long")
+
+	// ErrTooShort is returned when the value of a query parameter is
+	// shorter than MinLength.
+	ErrTooShort = errors.New("value too short")
+
+	// ErrPattern is returned when the value of a query parameter does not
+	// match the regular expression given with Pattern.
+	ErrPattern = errors.New("value does not match the pattern")
+
+	// ErrCharset is returned when the value of a query parameter contains
+	// a character not allowed by Charset.
+	ErrCharset = errors.New("invalid character")
+
+	// ErrTooLarge is returned when the query or the value of a query
+	// parameter exceeds the Limits, before it is split or parsed.
+	ErrTooLarge = errors.New("input too large")
+
+	// ErrUnknownKey is returned for a query parameter that was not read
+	// from a strict Query, see Query.Strict.
+	ErrUnknownKey = errors.New("unknown parameter")
+)
+
+// ParamError describes a failure to parse or validate the value
+// of a query parameter.
+//
+// The Err field holds one of the sentinel errors of the package, so
+// the kind of failure can be checked with errors.Is:
+//
+//	result := qp.ParseInt(u, "age", 18, 30)
+//	if errors.Is(result.Error, qp.ErrOutOfRange) {
+//	    // ...
+//	}
+//
+// The details can be obtained with errors.As:
+//
+//	var e *qp.ParamError
+//	if errors.As(result.Error, &e) {
+//	    fmt.Println(e.Key, e.Raw, e.Constraint)
+//	}
+type ParamError struct {
+	Key        string // the query parameter name
+	Raw        string // the raw value that failed
+	Index      int    // the index of the element in a slice, -1 otherwise
+	Constraint string // the violated constraint, if any
+	Err        error  // the sentinel error describing the failure
+}
+
+// Error returns the error message, e.g.:
+// "value out of range for key age: 55 (range [18, 30])".
+func (e *ParamError) Error() string {
+	msg := e.Err.Error() + " for key " + e.Key
+	if e.Err == ErrRequired || e.Err == ErrEmpty {
+		return msg // no value
+	} else if e.Index >= 0 {
+		msg += "[" + strconv.Itoa(e.Index) + "]"
+	}
+
+	msg += ": " + e.Raw
+	if e.Constraint != "" {
+		msg += " (" + e.Constraint + ")"
+	}
+
+	return msg
+}
+
+// Unwrap returns the sentinel error.
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// newParamError returns a new ParamError for the scalar value.
+func newParamError(err error, key, raw, constraint string) *ParamError {
+	return &ParamError{
+		Key:        key,
+		Raw:        raw,
+		Index:      -1,
+		Constraint: constraint,
+		Err:        err,
+	}
+}
+
+// syntaxError returns the error for a value that cannot be parsed.
+// The index is the element index for slices or -1 for scalar values.
+//
+// The errors of the strconv package are inspected, so a number that
+// does not fit the type is reported as ErrOutOfRange. The errors of
+// registered parsers may wrap ErrOutOfRange or ErrNotAllowed, the
+// internal parsers may return a *constraintError.
+func syntaxError(err error, key, raw string, index int, kind string) error {
+	result := newParamError(ErrInvalidSyntax, key, raw, "")
+	result.Index = index
+
+	var e *constraintError
+	switch {
+	case errors.Is(err, strconv.ErrRange):
+		result.Err = ErrOutOfRange
+		result.Constraint = kind
+	case errors.As(err, &e):
+		result.Err, result.Constraint = e.err, e.constraint
+	case errors.Is(err, ErrOutOfRange):
+		result.Err = ErrOutOfRange
+	case errors.Is(err, ErrNotAllowed):
+		result.Err = ErrNotAllowed
+	}
+
+	return result
+}
+
+// constraintError is the error of a parser that knows the violated
+// constraint, e.g. the names of an enum. The sentinel error and the
+// constraint are copied to the ParamError by syntaxError.
+type constraintError struct {
+	err        error
+	constraint string
+}
+
+// Error returns the error message, e.g.:
+// "value not allowed (one of [asc desc])".
+func (e *constraintError) Error() string {
+	return e.err.Error() + " (" + e.constraint + ")"
+}
+
+// Unwrap returns the sentinel error.
+func (e *constraintError) Unwrap() error {
+	return e.err
+}
+
+// requiredError returns the error for a required query parameter that
+// is absent or empty.
+func requiredError(key string, contains bool) error {
+	if !contains {
+		return newParamError(ErrRequired, key, "", "")
+	}
+
+	return newParamError(ErrEmpty, key, "", "")
+}
+
+// invalidOption returns the error for invalid options of the parameter.
+func invalidOption(key string, err error) error {
+	return fmt.Errorf("%w for key %s: %w", ErrInvalidOption, key, err)
+}
@@ -0,0 +1,183 @@
+package qp
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+)
+
+// TestParamError tests the errors returned by the parsers.
+func TestParamError(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		parse    func(u *url.URL) error
+		err      error
+		index    int
+		raw      string
+		expected string
+	}{
+		{
+			name:  "Invalid int",
+			query: "age=hello",
+			parse: func(u *url.URL) error {
+				return ParseInt(u, "age").Error
+			},
+			err:      ErrInvalidSyntax,
+			index:    -1,
+			raw:      "hello",
+			expected: "invalid value for key age: hello",
+		},
+		{
+			name:  "Int overflow",
+			query: "age=99999999999999999999",
+			parse: func(u *url.URL) error {
+				return ParseInt(u, "age").Error
+			},
+			err:   ErrOutOfRange,
+			index: -1,
+			raw:   "99999999999999999999",
+			expected: "value out of range for key age: " +
+				"99999999999999999999 (int)",
+		},
+		{
+			name:  "Required",
+			query: "",
+			parse: func(u *url.URL) error {
+				return Int(u, "id", Required()).Error
+			},
+			err:      ErrRequired,
+			index:    -1,
+			expected: "required parameter is missing for key id",
+		},
+		{
+			name:  "Required empty slice",
+			query: "ids=",
+			parse: func(u *url.URL) error {
+				return IntSlice(u, "ids", Required()).Error
+			},
+			err:      ErrEmpty,
+			index:    -1,
+			expected: "required parameter is empty for key ids",
+		},
+		{
+			name:  "Duplicate",
+			query: "age=20&age=30",
+			parse: func(u *url.URL) error {
+				return Int(u, "age", Duplicates(DuplicateReject)).Error
+			},
+			err:      ErrDuplicate,
+			index:    -1,
+			raw:      "20",
+			expected: "duplicate parameter for key age: 20 (2 values)",
+		},
+		{
+			name:  "Int out of range",
+			query: "age=55",
+			parse: func(u *url.URL) error {
+				return ParseInt(u, "age", 18, 30, 40).Error
+			},
+			err:   ErrOutOfRange,
+			index: -1,
+			raw:   "55",
+			expected: "value out of range for key age: 55 " +
+				"(range [18, 30] or one of [40])",
+		},
+		{
+			name:  "Float out of range",
+			query: "t=5.5",
+			parse: func(u *url.URL) error {
+				return ParseFloat(u, "t", 1.5, 2.5).Error
+			},
+			err:      ErrOutOfRange,
+			index:    -1,
+			raw:      "5.5",
+			expected: "value out of range for key t: 5.5 (range [1.5, 2.5])",
+		},
+		{
+			name:  "String not allowed",
+			query: "role=root",
+			parse: func(u *url.URL) error {
+				return ParseString(u, "role", "user", "admin").Error
+			},
+			err:   ErrNotAllowed,
+			index: -1,
+			raw:   "root",
+			expected: "value not allowed for key role: root " +
+				"(one of [user admin])",
+		},
+		{
+			name:  "Invalid bool",
+			query: "active=maybe",
+			parse: func(u *url.URL) error {
+				return ParseBool(u, "active").Error
+			},
+			err:      ErrInvalidSyntax,
+			index:    -1,
+			raw:      "maybe",
+			expected: "invalid value for key active: maybe",
+		},
+		{
+			name:  "Invalid slice element",
+			query: "ids=1,2,x",
+			parse: func(u *url.URL) error {
+				return ParseIntSlice(u, "ids").Error
+			},
+			err:      ErrInvalidSyntax,
+			index:    2,
+			raw:      "x",
+			expected: "invalid value for key ids[2]: x",
+		},
+		{
+			name:  "Invalid repeated element",
+			query: "t=1.5&t=y",
+			parse: func(u *url.URL) error {
+				return ParseFloatSlice(u, "t").Error
+			},
+			err:      ErrInvalidSyntax,
+			index:    1,
+			raw:      "y",
+			expected: "invalid value for key t[1]: y",
+		},
+		{
+			name:  "Invalid bool element",
+			query: "flags=on,maybe",
+			parse: func(u *url.URL) error {
+				return ParseBoolSlice(u, "flags").Error
+			},
+			err:      ErrInvalidSyntax,
+			index:    1,
+			raw:      "maybe",
+			expected: "invalid value for key flags[1]: maybe",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			err := tc.parse(u)
+
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("errors.Is(): got = %v, want %v", err, tc.err)
+			}
+
+			var e *ParamError
+			if !errors.As(err, &e) {
+				t.Fatalf("errors.As(): got = %T, want *ParamError", err)
+			}
+
+			if e.Index != tc.index {
+				t.Errorf(".Index: got = %d, want %d", e.Index, tc.index)
+			}
+
+			if e.Raw != tc.raw {
+				t.Errorf(".Raw: got = %s, want %s", e.Raw, tc.raw)
+			}
+
+			if err.Error() != tc.expected {
+				t.Errorf(".Error(): got = %s, want %s",
+					err.Error(), tc.expected)
+			}
+		})
+	}
+}
@@ -1,14 +1,17 @@
 package qp
 
 import (
-	"fmt"
 	"net/url"
+	"reflect"
 	"strconv"
-	"strings"
-
-	"github.com/goloop/g"
 )
 
+// Floating is a constraint that permits any floating-point type,
+// including named types based on them.
+type Floating interface {
+	~float32 | ~float64
+}
+
 // ParseFloat parses a float query parameter from the given URL.
 //
 // The function accepts a URL, a key, and an optional list of floats.
@@ -62,70 +65,7 @@ import (
 //	// Additional: 10.5, 20.0, 30.0
 //	result := ParseFloat(u, "temperature", 10.5, 10.5, 20.0, 30.0)
 func ParseFloat(u *url.URL, key string, opt ...float64) *Result[float64] {
-	result := &Result[float64]{Key: key, Contains: true}
-	data, ok := u.Query()[key]
-
-	// Available values.
-	if len(opt) == 1 {
-		// Default value.
-		result.Default = opt[0]
-		result.Value = result.Default
-	} else if len(opt) > 1 {
-		// Range and default value.
-		min, max := opt[0], opt[1]
-		if min > max {
-			min, max = max, min
-		}
-
-		result.Min = min
-		result.Max = max
-		result.Default = opt[0] // not min or max, but first value
-		result.Value = result.Default
-
-		// Set additional valid values.
-		if len(opt) > 2 {
-			result.Others = make([]float64, 0, len(opt)-2)
-			result.Others = append(result.Others, opt[2:]...)
-		}
-	}
-
-	// Check if the query parameter is empty or missing.
-	if !ok {
-		// The query parameter is missing.
-		result.Empty = true
-		result.Contains = false
-		return result
-	} else if data[0] == "" {
-		// The query parameter is empty.
-		result.Empty = true
-		result.Contains = true
-		return result
-	}
-
-	// Convert the result to a float.
-	value, err := strconv.ParseFloat(data[0], 64)
-	if err != nil {
-		result.Error = fmt.Errorf("invalid value for key %s: %s", key, data[0])
-		return result
-	}
-
-	if len(opt) < 2 {
-		// No range or any available values.
-		result.Value = value
-	} else if value >= result.Min && value <= result.Max {
-		// Check if the value is within the specified range.
-		result.Value = value
-	} else {
-		// Check if the value is in the list of available values.
-		if result.Others != nil && g.In(value, result.Others...) {
-			result.Value = value
-		} else {
-			msg := "value out of range for key %s: %f"
-			result.Error = fmt.Errorf(msg, key, value)
-		}
-	}
-
-	return result
+	return New(u).Float(key, opt...)
 }
 
 // GetFloat is the function to parse a float query parameter and return
@@ -278,60 +218,7 @@ func ParseFloatSlice(
 	key string,
 	opt ...[]float64,
 ) *Result[[]float64] {
-	result := &Result[[]float64]{Key: key, Contains: true}
-	data, ok := u.Query()[key]
-
-	// Default value.
-	result.Default = []float64{} // not nil
-	result.Value = result.Default
-	if len(opt) > 0 {
-		result.Default = opt[0]
-		result.Value = result.Default
-	}
-
-	// Check if the query parameter is empty or missing.
-	if !ok {
-		result.Empty = true
-		result.Contains = false
-		return result
-	} else if data[0] == "" {
-		result.Empty = true
-		result.Contains = true
-		return result
-	}
-
-	// An array can be specified as a single string "?values=1.1,2.2,3.3" or
-	// as multiple values "?values=1.1&values=2.2&values=3.3".
-	if len(data) > 1 {
-		// Multiple values.
-		result.Value = make([]float64, 0, len(data))
-		for _, str := range data {
-			value, err := strconv.ParseFloat(str, 64)
-			if err != nil {
-				msg := "invalid value for key %s: %s"
-				result.Error = fmt.Errorf(msg, key, str)
-				result.Value = []float64{} // not nil
-				return result
-			}
-			result.Value = append(result.Value, value)
-		}
-		return result
-	}
-
-	// Single value.
-	result.Value = make([]float64, 0)
-	for _, str := range strings.Split(data[0], ",") {
-		value, err := strconv.ParseFloat(str, 64)
-		if err != nil {
-			msg := "invalid value for key %s: %s"
-			result.Error = fmt.Errorf(msg, key, str)
-			result.Value = []float64{} // not nil
-			return result
-		}
-		result.Value = append(result.Value, value)
-	}
-
-	return result
+	return New(u).FloatSlice(key, opt...)
 }
 
 // GetFloatSlice parses an float64 slice query parameter from the given URL
@@ -393,3 +280,85 @@ func PullFloatSlice(u *url.URL, key string, opt ...[]float64) []float64 {
 
 	return data.Value
 }
+
+// Float parses a float query parameter from the given source with
+// the options, see Option. The source is a *url.URL or a *Query.
+//
+// Example Usage:
+//
+//	// Default: 36.6, Range: 35.0-42.0
+//	result := Float(u, "temperature", Default(36.6), Min(35), Max(42))
+func Float(src Source, key string, opts ...Option) *Result[float64] {
+	p := float64Parser
+	return scalar(query(src), key, resolve(newOptions(opts), p, false), p)
+}
+
+// FloatSlice parses a float slice query parameter from the given source
+// with the options. Default takes a slice, the other options apply to
+// every element.
+//
+// Example Usage:
+//
+//	result := FloatSlice(u, "weights", Min(0), Max(1))
+func FloatSlice(src Source, key string, opts ...Option) *Result[[]float64] {
+	p := float64Parser
+	return slice(query(src), key, resolve(newOptions(opts), p, true), p)
+}
+
+// Float parses a float query parameter.
+// See ParseFloat for details.
+func (q *Query) Float(key string, opt ...float64) *Result[float64] {
+	return scalar(q, key, legacySpec(opt), float64Parser)
+}
+
+// GetFloat returns the float query parameter value and a boolean
+// indicating if it is present and valid. See GetFloat for details.
+func (q *Query) GetFloat(key string, opt ...float64) (float64, bool) {
+	data := q.Float(key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullFloat returns a pointer to the float query parameter value
+// or nil if it is absent. See PullFloat for details.
+func (q *Query) PullFloat(key string, opt ...float64) *float64 {
+	data := q.Float(key, opt...)
+	if !data.Contains {
+		return nil
+	}
+
+	return &data.Value
+}
+
+// FloatSlice parses a float slice query parameter.
+// See ParseFloatSlice for details.
+func (q *Query) FloatSlice(key string, opt ...[]float64) *Result[[]float64] {
+	return slice(q, key, legacySliceSpec(opt), float64Parser)
+}
+
+// GetFloatSlice returns the float slice query parameter value and a boolean
+// indicating if it is present and valid. See GetFloatSlice for details.
+func (q *Query) GetFloatSlice(key string, opt ...[]float64) ([]float64, bool) {
+	data := q.FloatSlice(key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullFloatSlice returns the float slice query parameter value or nil
+// if it is absent. See PullFloatSlice for details.
+func (q *Query) PullFloatSlice(key string, opt ...[]float64) []float64 {
+	data := q.FloatSlice(key, opt...)
+	if !data.Contains {
+		return nil // not default
+	}
+
+	return data.Value
+}
+
+// float64Parser is the parser of float64 values.
+var float64Parser = numberParser(parseFloating[float64])
+
+// parseFloating parses a string as a float with the bit size of T.
+// A value that does not fit the type is reported as strconv.ErrRange.
+func parseFloating[T Floating](str string) (T, error) {
+	value, err := strconv.ParseFloat(str, reflect.TypeFor[T]().Bits())
+	return T(value), err
+}
@@ -0,0 +1,200 @@
+package qp
+
+import "net/url"
+
+// ParseFloat32 parses a float32 query parameter from the given URL.
+//
+// The value is parsed with a bit size of 32, so it is rounded to the
+// nearest float32 exactly once, and a value that does not fit float32
+// (e.g., 3.4e39) is reported as ErrOutOfRange instead of silently
+// becoming +Inf. The range and the additional valid values are compared
+// with the parsed float32 value.
+//
+// The optional floats have the same meaning as for ParseFloat: the first
+// one is the default value, the first two specify the range (min and max)
+// and any additional floats are treated as additional valid values.
+//
+// Example Usage:
+//
+//	// Simple call without default, min, max, or others.
+//	result := ParseFloat32(u, "lat")
+//
+//	// Call with default and min-max.
+//	// Default: 0.5
+//	// Range:   0.5-2.0
+//	result := ParseFloat32(u, "scale", 0.5, 2.0)
+//
+//	// Call with default, min-max, and additional valid values.
+//	// Default:    1.0
+//	// Range:      1.0-2.0
+//	// Additional: 4.0
+//	result := ParseFloat32(u, "scale", 1.0, 2.0, 4.0)
+func ParseFloat32(u *url.URL, key string, opt ...float32) *Result[float32] {
+	return New(u).Float32(key, opt...)
+}
+
+// GetFloat32 parses a float32 query parameter and returns the value and
+// a boolean indicating, true - if a value was passed in query params and
+// successfully parsed.
+//
+// The optional floats have the same meaning as for ParseFloat32.
+//
+// Example Usage:
+//
+//	// Default: 1.0
+//	// Range:   1.0-2.0
+//	scale, ok := GetFloat32(u, "scale", 1.0, 2.0)
+func GetFloat32(u *url.URL, key string, opt ...float32) (float32, bool) {
+	data := ParseFloat32(u, key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullFloat32 returns a pointer to the parsed float32 query parameter
+// value, or nil if the parameter is absent.
+//
+// If the parameter is specified, but it is empty or invalid, a pointer to
+// the default value is returned. The optional floats have the same meaning
+// as for ParseFloat32.
+//
+// Example Usage:
+//
+//	lat := PullFloat32(u, "lat")
+func PullFloat32(u *url.URL, key string, opt ...float32) *float32 {
+	data := ParseFloat32(u, key, opt...)
+	if !data.Contains {
+		return nil
+	}
+
+	return &data.Value
+}
+
+// ParseFloat32Slice parses a float32 slice query parameter from the
+// given URL.
+//
+// The function supports query parameters specified as a single string
+// (e.g., "?point=1.5,2.5") or as multiple values (e.g.,
+// "?point=1.5&point=2.5"). Every element is parsed with a bit size of 32,
+// an element that does not fit float32 is reported as ErrOutOfRange.
+//
+// Example Usage:
+//
+//	result := ParseFloat32Slice(u, "point")
+func ParseFloat32Slice(
+	u *url.URL,
+	key string,
+	opt ...[]float32,
+) *Result[[]float32] {
+	return New(u).Float32Slice(key, opt...)
+}
+
+// GetFloat32Slice parses a float32 slice query parameter and returns
+// the slice of values and a boolean indicating if the value is valid.
+//
+// Example Usage:
+//
+//	point, ok := GetFloat32Slice(u, "point")
+func GetFloat32Slice(
+	u *url.URL,
+	key string,
+	opt ...[]float32,
+) ([]float32, bool) {
+	data := ParseFloat32Slice(u, key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullFloat32Slice parses a float32 slice query parameter and returns
+// the slice of values, or nil if the parameter is absent.
+//
+// Example Usage:
+//
+//	point := PullFloat32Slice(u, "point")
+func PullFloat32Slice(u *url.URL, key string, opt ...[]float32) []float32 {
+	data := ParseFloat32Slice(u, key, opt...)
+	if !data.Contains {
+		return nil // not default
+	}
+
+	return data.Value
+}
+
+// Float32 parses a float32 query parameter from the given source with
+// the options, see Option. The source is a *url.URL or a *Query.
+//
+// Example Usage:
+//
+//	// Range: -90-90
+//	result := Float32(u, "lat", Min(-90), Max(90))
+func Float32(src Source, key string, opts ...Option) *Result[float32] {
+	p := float32Parser
+	return scalar(query(src), key, resolve(newOptions(opts), p, false), p)
+}
+
+// Float32Slice parses a float32 slice query parameter from the given source
+// with the options. Default takes a slice, the other options apply to
+// every element.
+//
+// Example Usage:
+//
+//	result := Float32Slice(u, "point", Default([]float32{0, 0}))
+func Float32Slice(src Source, key string, opts ...Option) *Result[[]float32] {
+	p := float32Parser
+	return slice(query(src), key, resolve(newOptions(opts), p, true), p)
+}
+
+// Float32 parses a float32 query parameter.
+// See ParseFloat32 for details.
+func (q *Query) Float32(key string, opt ...float32) *Result[float32] {
+	return scalar(q, key, legacySpec(opt), float32Parser)
+}
+
+// GetFloat32 returns the float32 query parameter value and a boolean
+// indicating if it is present and valid. See GetFloat32 for details.
+func (q *Query) GetFloat32(key string, opt ...float32) (float32, bool) {
+	data := q.Float32(key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullFloat32 returns a pointer to the float32 query parameter value
+// or nil if it is absent. See PullFloat32 for details.
+func (q *Query) PullFloat32(key string, opt ...float32) *float32 {
+	data := q.Float32(key, opt...)
+	if !data.Contains {
+		return nil
+	}
+
+	return &data.Value
+}
+
+// Float32Slice parses a float32 slice query parameter.
+// See ParseFloat32Slice for details.
+func (q *Query) Float32Slice(
+	key string,
+	opt ...[]float32,
+) *Result[[]float32] {
+	return slice(q, key, legacySliceSpec(opt), float32Parser)
+}
+
+// GetFloat32Slice returns the float32 slice query parameter value and
+// a boolean indicating if it is present and valid.
+// See GetFloat32Slice for details.
+func (q *Query) GetFloat32Slice(
+	key string,
+	opt ...[]float32,
+) ([]float32, bool) {
+	data := q.Float32Slice(key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullFloat32Slice returns the float32 slice query parameter value or nil
+// if it is absent. See PullFloat32Slice for details.
+func (q *Query) PullFloat32Slice(key string, opt ...[]float32) []float32 {
+	data := q.Float32Slice(key, opt...)
+	if !data.Contains {
+		return nil // not default
+	}
+
+	return data.Value
+}
+
+// float32Parser is the parser of float32 values.
+var float32Parser = numberParser(parseFloating[float32])
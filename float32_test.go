@@ -0,0 +1,209 @@
+package qp
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/goloop/g"
+)
+
+// TestParseFloat32 tests the ParseFloat32 function.
+func TestParseFloat32(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		opt      []float32
+		value    float32
+		contains bool
+		empty    bool
+		err      error
+	}{
+		{
+			name:     "Simple call",
+			query:    "lat=50.45",
+			value:    50.45,
+			contains: true,
+		},
+		{
+			name:  "Absent",
+			query: "",
+			opt:   []float32{1.5},
+			value: 1.5,
+			empty: true,
+		},
+		{
+			name:     "Empty",
+			query:    "lat=",
+			opt:      []float32{1.5},
+			value:    1.5,
+			contains: true,
+			empty:    true,
+		},
+		{
+			name:     "Overflow",
+			query:    "lat=3.4e39",
+			opt:      []float32{1.5},
+			value:    1.5,
+			contains: true,
+			err:      ErrOutOfRange,
+		},
+		{
+			name:     "Negative overflow",
+			query:    "lat=-3.4e39",
+			value:    0,
+			contains: true,
+			err:      ErrOutOfRange,
+		},
+		{
+			name:     "Max float32",
+			query:    "lat=3.4028235e38",
+			value:    math.MaxFloat32,
+			contains: true,
+		},
+		{
+			name:     "Invalid",
+			query:    "lat=north",
+			value:    0,
+			contains: true,
+			err:      ErrInvalidSyntax,
+		},
+		{
+			// 0.1 is not representable exactly, the range is
+			// compared with the value rounded to float32.
+			name:     "Range in float32 space",
+			query:    "lat=0.1",
+			opt:      []float32{0.1, 0.2},
+			value:    0.1,
+			contains: true,
+		},
+		{
+			name:     "Out of range",
+			query:    "lat=0.3",
+			opt:      []float32{0.1, 0.2},
+			value:    0.1,
+			contains: true,
+			err:      ErrOutOfRange,
+		},
+		{
+			name:     "Additional value",
+			query:    "lat=0.3",
+			opt:      []float32{0.1, 0.2, 0.3},
+			value:    0.3,
+			contains: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			result := ParseFloat32(u, "lat", tc.opt...)
+
+			if result.Value != tc.value {
+				t.Errorf(".Value: got = %v, want %v", result.Value, tc.value)
+			}
+
+			if result.Contains != tc.contains || result.Empty != tc.empty {
+				t.Errorf(".Contains, .Empty: got = %v, %v, want %v, %v",
+					result.Contains, result.Empty, tc.contains, tc.empty)
+			}
+
+			if !errors.Is(result.Error, tc.err) {
+				t.Errorf(".Error: got = %v, want %v", result.Error, tc.err)
+			}
+		})
+	}
+}
+
+// TestGetPullFloat32 tests the GetFloat32 and PullFloat32 functions.
+func TestGetPullFloat32(t *testing.T) {
+	u, _ := url.Parse("http://example.com?scale=2.5&zoom=3.4e39")
+
+	if value, ok := GetFloat32(u, "scale", 1.0, 3.0); !ok || value != 2.5 {
+		t.Errorf("GetFloat32(): got = %v, %v, want 2.5, true", value, ok)
+	}
+
+	if value, ok := GetFloat32(u, "zoom", 1.0); ok || value != 1.0 {
+		t.Errorf("GetFloat32(): got = %v, %v, want 1, false", value, ok)
+	}
+
+	if value := PullFloat32(u, "scale"); !reflect.DeepEqual(value,
+		g.Ptr(float32(2.5))) {
+		t.Errorf("PullFloat32(): got = %v, want 2.5", value)
+	}
+
+	if value := PullFloat32(u, "absent"); value != nil {
+		t.Errorf("PullFloat32(): got = %v, want nil", *value)
+	}
+}
+
+// TestParseFloat32Slice tests the float32 slice functions.
+func TestParseFloat32Slice(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected []float32
+		index    int
+		err      error
+	}{
+		{
+			name:     "Single value",
+			query:    "point=30.52,50.45",
+			expected: []float32{30.52, 50.45},
+		},
+		{
+			name:     "Multiple values",
+			query:    "point=30.52&point=50.45",
+			expected: []float32{30.52, 50.45},
+		},
+		{
+			name:     "Overflow",
+			query:    "point=1,3.4e39",
+			expected: []float32{},
+			index:    1,
+			err:      ErrOutOfRange,
+		},
+		{
+			name:     "Invalid",
+			query:    "point=x&point=1",
+			expected: []float32{},
+			index:    0,
+			err:      ErrInvalidSyntax,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			result := ParseFloat32Slice(u, "point")
+
+			if !reflect.DeepEqual(result.Value, tc.expected) {
+				t.Errorf(".Value: got = %v, want %v",
+					result.Value, tc.expected)
+			}
+
+			if !errors.Is(result.Error, tc.err) {
+				t.Fatalf(".Error: got = %v, want %v", result.Error, tc.err)
+			}
+
+			var e *ParamError
+			if errors.As(result.Error, &e) && e.Index != tc.index {
+				t.Errorf(".Index: got = %d, want %d", e.Index, tc.index)
+			}
+
+			value, ok := GetFloat32Slice(u, "point")
+			if ok != (tc.err == nil) ||
+				!reflect.DeepEqual(value, tc.expected) {
+				t.Errorf("GetFloat32Slice(): got = %v, %v", value, ok)
+			}
+
+			pulled := PullFloat32Slice(u, "point")
+			if !reflect.DeepEqual(pulled, tc.expected) {
+				t.Errorf("PullFloat32Slice(): got = %v, want %v",
+					pulled, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package qp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Parse parses a query parameter of any Value type, or of a type
+// registered with RegisterParser, from the given source.
+//
+// The source is a *url.URL or a *Query. The parser is chosen by the
+// underlying type of T, so named types such as `type Status string` are
+// parsed like their underlying types, time.Duration is parsed like
+// ParseDuration, time.Time like ParseTime with DefaultTimeLayouts in UTC,
+// and slice types like the corresponding *Slice functions. A registered
+// type, or a slice of it, is parsed with its registered parser, a type
+// that implements encoding.TextUnmarshaler (e.g. netip.Addr or *big.Int)
+// with its UnmarshalText method.
+// The options have the same meaning as for Int, String and the
+// other functions with options, see Option.
+//
+// For any other type the Error field of the result wraps
+// errors.ErrUnsupported.
+//
+// Example Usage:
+//
+//	type Status string
+//
+//	// Default: active
+//	// Valid:   active, archived
+//	status := qp.Parse[Status](u, "status", qp.Default("active"),
+//	    qp.OneOf("active", "archived"))
+//
+//	// Slices, e.g. "?ids=1,2,3".
+//	ids := qp.Parse[[]uint32](u, "ids", qp.Max(1000))
+//
+//	// A generic helper on top of qp.
+//	func param[T any](r *http.Request, key string) (T, error) {
+//	    result := qp.Parse[T](r.URL, key)
+//	    return result.Value, result.Error
+//	}
+func Parse[T any](src Source, key string, opts ...Option) *Result[T] {
+	q, o := query(src), newOptions(opts)
+
+	typ := reflect.TypeFor[T]()
+	if r := parserOf(typ); r != nil {
+		return parseScalar[T](q, key, o, r)
+	} else if typ.Kind() == reflect.Slice {
+		if r := parserOf(typ.Elem()); r != nil {
+			return parseSlice[T](q, key, o, r)
+		}
+	}
+
+	return unsupported[T](q, key, typ)
+}
+
+// Get parses a query parameter of any supported type and returns the
+// value and a boolean indicating, true - if a value was passed in query params
+// and successfully parsed. See Parse for details.
+//
+// Example Usage:
+//
+//	limit, ok := qp.Get[int](u, "limit", qp.Default(20), qp.Max(100))
+func Get[T any](src Source, key string, opts ...Option) (T, bool) {
+	data := Parse[T](src, key, opts...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// Pull returns a pointer to the parsed query parameter value of any
+// supported type, or nil if the parameter is absent. See Parse for details.
+//
+// If the parameter is specified, but it is empty or invalid, a pointer to
+// the default value is returned. For slice types the pointer points to the
+// slice.
+//
+// Example Usage:
+//
+//	status := qp.Pull[Status](u, "status")
+func Pull[T any](src Source, key string, opts ...Option) *T {
+	data := Parse[T](src, key, opts...)
+	if !data.Contains {
+		return nil
+	}
+
+	return &data.Value
+}
+
+// parseScalar parses a query parameter with the parsing functions of
+// T, or of its underlying type, and converts the result to T.
+func parseScalar[T any](
+	q *Query,
+	key string,
+	o options,
+	r *registration,
+) *Result[T] {
+	if p, ok := r.parser.(parser[T]); ok {
+		return scalar(q, key, resolve(o, p, false), p)
+	}
+
+	data := r.scalar(q, key, o)
+	result := &Result[T]{
+		Key:      data.Key,
+		Value:    convertTo[T](data.Value),
+		Default:  convertTo[T](data.Default),
+		Min:      convertTo[T](data.Min),
+		Max:      convertTo[T](data.Max),
+		Empty:    data.Empty,
+		Contains: data.Contains,
+		Count:    data.Count,
+		Adjusted: data.Adjusted,
+		Error:    data.Error,
+	}
+
+	for _, v := range data.Others {
+		result.Others = append(result.Others, convertTo[T](v))
+	}
+
+	return result
+}
+
+// parseSlice parses a slice query parameter with the parsing functions
+// of the element type, T is the slice type.
+func parseSlice[T any](
+	q *Query,
+	key string,
+	o options,
+	r *registration,
+) *Result[T] {
+	if f, ok := r.sliceOf.(func(*Query, string, options) *Result[T]); ok {
+		return f(q, key, o)
+	}
+
+	data := r.slice(q, key, o)
+	return &Result[T]{
+		Key:      data.Key,
+		Value:    convertTo[T](data.Value),
+		Default:  convertTo[T](data.Default),
+		Empty:    data.Empty,
+		Contains: data.Contains,
+		Count:    data.Count,
+		Adjusted: data.Adjusted,
+		Error:    data.Error,
+	}
+}
+
+// unsupported returns the result for a type that cannot be parsed.
+func unsupported[T any](q *Query, key string, typ reflect.Type) *Result[T] {
+	data, ok := q.lookup(key)
+	return &Result[T]{
+		Key:      key,
+		Empty:    len(data) == 0 || data[0] == "",
+		Contains: ok,
+		Count:    len(data),
+		Error: fmt.Errorf("type %s of key %s: %w", typ, key,
+			errors.ErrUnsupported),
+	}
+}
+
+// convertTo converts the value to T, which has the same underlying type
+// or, for slices, the same underlying element type. The elements may be
+// held in interfaces. A nil value or slice becomes the zero value.
+func convertTo[T any](value any) T {
+	var result T
+	if v, ok := value.(T); ok {
+		return v
+	} else if value == nil {
+		return result
+	}
+
+	rv, typ := reflect.ValueOf(value), reflect.TypeFor[T]()
+	if rv.Kind() != reflect.Slice {
+		return rv.Convert(typ).Interface().(T)
+	} else if rv.IsNil() {
+		return result
+	}
+
+	dst := reflect.MakeSlice(typ, rv.Len(), rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		v := rv.Index(i)
+		if v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+		dst.Index(i).Set(v.Convert(typ.Elem()))
+	}
+
+	return dst.Interface().(T)
+}
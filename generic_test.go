@@ -0,0 +1,243 @@
+package qp
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// genericStatus is a named string type used to test the generic parsers.
+type genericStatus string
+
+// genericIDs is a named slice type used to test the generic parsers.
+type genericIDs []uint16
+
+// TestParse tests the Parse function for every kind of Value type.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		parse func(u *url.URL) (any, error)
+		value any
+		err   error
+	}{
+		{
+			name:  "Int",
+			query: "x=42",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[int](u, "x")
+				return r.Value, r.Error
+			},
+			value: 42,
+		},
+		{
+			name:  "Uint8 out of range of the type",
+			query: "x=300",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[uint8](u, "x", Default(1))
+				return r.Value, r.Error
+			},
+			value: uint8(1),
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "Float32 with options",
+			query: "x=2.5",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[float32](u, "x", Min(0), Max(1))
+				return r.Value, r.Error
+			},
+			value: float32(0),
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "Bool",
+			query: "x=yes",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[bool](u, "x")
+				return r.Value, r.Error
+			},
+			value: true,
+		},
+		{
+			name:  "Named string",
+			query: "status=archived",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[genericStatus](u, "status", Default("active"),
+					OneOf("active", genericStatus("archived")))
+				return r.Value, r.Error
+			},
+			value: genericStatus("archived"),
+		},
+		{
+			name:  "Named string not allowed",
+			query: "status=deleted",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[genericStatus](u, "status", Default("active"),
+					OneOf("active", "archived"))
+				return r.Value, r.Error
+			},
+			value: genericStatus("active"),
+			err:   ErrNotAllowed,
+		},
+		{
+			name:  "Duration",
+			query: "ttl=PT1M",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[time.Duration](u, "ttl", Max("1h"))
+				return r.Value, r.Error
+			},
+			value: time.Minute,
+		},
+		{
+			name:  "Slice",
+			query: "ids=1,2,3",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[[]int64](u, "ids")
+				return r.Value, r.Error
+			},
+			value: []int64{1, 2, 3},
+		},
+		{
+			name:  "Slice of strings",
+			query: "tags=a&tags=b",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[[]string](u, "tags")
+				return r.Value, r.Error
+			},
+			value: []string{"a", "b"},
+		},
+		{
+			name:  "Named slice",
+			query: "ids=1,2",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[genericIDs](u, "ids", Max(10))
+				return r.Value, r.Error
+			},
+			value: genericIDs{1, 2},
+		},
+		{
+			name:  "Named slice default",
+			query: "",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[genericIDs](u, "ids", Default(genericIDs{5}))
+				return r.Value, r.Error
+			},
+			value: genericIDs{5},
+		},
+		{
+			name:  "Named slice element out of range",
+			query: "ids=1,20",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[genericIDs](u, "ids", Max(10))
+				return r.Value, r.Error
+			},
+			value: genericIDs{},
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "Invalid option",
+			query: "x=1",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[bool](u, "x", Min(false))
+				return r.Value, r.Error
+			},
+			value: false,
+			err:   ErrInvalidOption,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			value, err := tc.parse(u)
+
+			if !reflect.DeepEqual(value, tc.value) {
+				t.Errorf(".Value: got = %#v, want %#v", value, tc.value)
+			}
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf(".Error: got = %v, want %v", err, tc.err)
+			}
+		})
+	}
+}
+
+// TestParseResult tests that Parse fills the result like the functions
+// of the type.
+func TestParseResult(t *testing.T) {
+	u, _ := url.Parse("http://example.com?age=55&role=root&ids=1,2")
+	roleErr := String(u, "role", Default("user"), OneOf("user")).Error
+
+	tests := []struct {
+		name     string
+		generic  any
+		specific any
+	}{
+		{
+			name:     "Int",
+			generic:  Parse[int](u, "age", Default(30), Min(18), OneOf(5)),
+			specific: Int(u, "age", Default(30), Min(18), OneOf(5)),
+		},
+		{
+			name:     "String",
+			generic:  Parse[string](u, "role", OneOf("user", "admin")),
+			specific: String(u, "role", OneOf("user", "admin")),
+		},
+		{
+			name:     "Int slice",
+			generic:  Parse[[]int](u, "ids", Default([]int{3})),
+			specific: IntSlice(u, "ids", Default([]int{3})),
+		},
+		{
+			name: "Named type",
+			generic: Parse[genericStatus](u, "role", Default("user"),
+				OneOf("user")),
+			specific: &Result[genericStatus]{
+				Key:      "role",
+				Value:    "user",
+				Default:  "user",
+				Others:   []genericStatus{"user"},
+				Contains: true,
+				Count:    1,
+				Error:    roleErr,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tc.generic, tc.specific) {
+				t.Errorf("got = %+v, want %+v", tc.generic, tc.specific)
+			}
+		})
+	}
+}
+
+// TestGetPull tests the Get and Pull functions.
+func TestGetPull(t *testing.T) {
+	u, _ := url.Parse("http://example.com?limit=50&bad=x&ids=1,2")
+
+	if v, ok := Get[int](u, "limit", Max(100)); !ok || v != 50 {
+		t.Errorf("Get(limit) = %v, %v, want 50, true", v, ok)
+	}
+
+	if v, ok := Get[int](u, "bad", Default(20)); ok || v != 20 {
+		t.Errorf("Get(bad) = %v, %v, want 20, false", v, ok)
+	}
+
+	if v := Pull[int](u, "missing"); v != nil {
+		t.Errorf("Pull(missing) = %v, want nil", *v)
+	}
+
+	if v := Pull[int](u, "bad", Default(20)); v == nil || *v != 20 {
+		t.Errorf("Pull(bad) = %v, want pointer to 20", v)
+	}
+
+	v := Pull[genericIDs](New(u), "ids")
+	if v == nil || !reflect.DeepEqual(*v, genericIDs{1, 2}) {
+		t.Errorf("Pull(ids) = %v, want pointer to [1 2]", v)
+	}
+}
@@ -2,6 +2,9 @@ module github.com/goloop/qp
 
 go 1.22.1
 
-require github.com/goloop/g v1.11.0
+require (
+	github.com/goloop/g v1.11.0
+	golang.org/x/text v0.22.0
+)
 
 require github.com/goloop/trit v1.7.1 // indirect
@@ -1,13 +1,6 @@
 package qp
 
-import (
-	"fmt"
-	"net/url"
-	"strconv"
-	"strings"
-
-	"github.com/goloop/g"
-)
+import "net/url"
 
 // ParseInt parses an integer query parameter from the given URL.
 //
@@ -32,6 +25,10 @@ import (
 // value is not within the range or among the additional valid values,
 // the default value is returned.
 //
+// The positional values are easy to mix up, Int with the Default, Min,
+// Max and OneOf options states the intent explicitly and also supports
+// one-sided ranges and valid values without a range.
+//
 // Example Usage:
 //
 //	// Simple call without default, min, max, or others.
@@ -62,70 +59,7 @@ import (
 //	// Additional: 10, 20, 30
 //	result := ParseInt(u, "age", 10, 10, 20, 30)
 func ParseInt(u *url.URL, key string, opt ...int) *Result[int] {
-	result := &Result[int]{Key: key, Contains: true}
-	data, ok := u.Query()[key]
-
-	// Available values.
-	if len(opt) == 1 {
-		// Default value.
-		result.Default = opt[0]
-		result.Value = result.Default
-	} else if len(opt) > 1 {
-		// Range and default value.
-		min, max := opt[0], opt[1]
-		if min > max {
-			min, max = max, min
-		}
-
-		result.Min = min
-		result.Max = max
-		result.Default = opt[0] // not min or max, but first value
-		result.Value = result.Default
-
-		// Set additional valid values.
-		if len(opt) > 2 {
-			result.Others = make([]int, 0, len(opt)-2)
-			result.Others = append(result.Others, opt[2:]...)
-		}
-	}
-
-	// Check if the query parameter is empty or missing.
-	if !ok {
-		// The query parameter is missing.
-		result.Empty = true
-		result.Contains = false
-		return result
-	} else if data[0] == "" {
-		// The query parameter is empty.
-		result.Empty = true
-		result.Contains = true
-		return result
-	}
-
-	// Convert the result to an integer.
-	value, err := strconv.Atoi(data[0])
-	if err != nil {
-		result.Error = fmt.Errorf("invalid value for key %s: %s", key, data[0])
-		return result
-	}
-
-	if len(opt) < 2 {
-		// No range or any available values.
-		result.Value = value
-	} else if value >= result.Min && value <= result.Max {
-		// Check if the value is within the specified range.
-		result.Value = value
-	} else {
-		// Check if the value is in the list of available values.
-		if result.Others != nil && g.In(value, result.Others...) {
-			result.Value = value
-		} else {
-			msg := "value out of range for key %s: %d"
-			result.Error = fmt.Errorf(msg, key, value)
-		}
-	}
-
-	return result
+	return New(u).Int(key, opt...)
 }
 
 // GetInt parses an integer query parameter and return the value and a
@@ -277,60 +211,7 @@ func PullInt(u *url.URL, key string, opt ...int) *int {
 //	    fmt.Println("Query parameter is absent.")
 //	}
 func ParseIntSlice(u *url.URL, key string, opt ...[]int) *Result[[]int] {
-	result := &Result[[]int]{Key: key, Contains: true}
-	data, ok := u.Query()[key]
-
-	// Default value.
-	result.Default = []int{} // not nil
-	result.Value = result.Default
-	if len(opt) > 0 {
-		result.Default = opt[0]
-		result.Value = result.Default
-	}
-
-	// Check if the query parameter is empty or missing.
-	if !ok {
-		result.Empty = true
-		result.Contains = false
-		return result
-	} else if data[0] == "" {
-		result.Empty = true
-		result.Contains = true
-		return result
-	}
-
-	// An array can be specified as a single string "?ids=1,2,3" or
-	// as multiple values "?ids=1&ids=2&ids=3".
-	if len(data) > 1 {
-		// Multiple values.
-		result.Value = make([]int, 0, len(data))
-		for _, str := range data {
-			value, err := strconv.Atoi(str)
-			if err != nil {
-				msg := "invalid value for key %s: %s"
-				result.Error = fmt.Errorf(msg, key, str)
-				result.Value = []int{} // not nil
-				return result
-			}
-			result.Value = append(result.Value, value)
-		}
-		return result
-	}
-
-	// Single value.
-	result.Value = make([]int, 0)
-	for _, str := range strings.Split(data[0], ",") {
-		value, err := strconv.Atoi(str)
-		if err != nil {
-			msg := "invalid value for key %s: %s"
-			result.Error = fmt.Errorf(msg, key, str)
-			result.Value = []int{} // not nil
-			return result
-		}
-		result.Value = append(result.Value, value)
-	}
-
-	return result
+	return New(u).IntSlice(key, opt...)
 }
 
 // GetIntSlice parses an integer slice query parameter from the given URL
@@ -394,3 +275,75 @@ func PullIntSlice(u *url.URL, key string, opt ...[]int) []int {
 
 	return data.Value
 }
+
+// Int parses an integer query parameter from the given source with
+// the options, see Option. The source is a *url.URL or a *Query.
+//
+// Example Usage:
+//
+//	// Default: 18, Range: 18-65, Additional: 70, 99
+//	result := Int(u, "age", Default(18), Min(18), Max(65), OneOf(70, 99))
+func Int(src Source, key string, opts ...Option) *Result[int] {
+	p := intParser
+	return scalar(query(src), key, resolve(newOptions(opts), p, false), p)
+}
+
+// IntSlice parses an integer slice query parameter from the given source
+// with the options. Default takes a slice, the other options apply to
+// every element.
+//
+// Example Usage:
+//
+//	result := IntSlice(u, "ids", Default([]int{1}), Min(1))
+func IntSlice(src Source, key string, opts ...Option) *Result[[]int] {
+	p := intParser
+	return slice(query(src), key, resolve(newOptions(opts), p, true), p)
+}
+
+// Int parses an integer query parameter.
+// See ParseInt for details.
+func (q *Query) Int(key string, opt ...int) *Result[int] {
+	return integer(q, key, opt)
+}
+
+// GetInt returns the integer query parameter value and a boolean
+// indicating if it is present and valid. See GetInt for details.
+func (q *Query) GetInt(key string, opt ...int) (int, bool) {
+	data := q.Int(key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullInt returns a pointer to the integer query parameter value
+// or nil if it is absent. See PullInt for details.
+func (q *Query) PullInt(key string, opt ...int) *int {
+	data := q.Int(key, opt...)
+	if !data.Contains {
+		return nil
+	}
+
+	return &data.Value
+}
+
+// IntSlice parses an integer slice query parameter.
+// See ParseIntSlice for details.
+func (q *Query) IntSlice(key string, opt ...[]int) *Result[[]int] {
+	return integerSlice(q, key, opt)
+}
+
+// GetIntSlice returns the integer slice query parameter value and a boolean
+// indicating if it is present and valid. See GetIntSlice for details.
+func (q *Query) GetIntSlice(key string, opt ...[]int) ([]int, bool) {
+	data := q.IntSlice(key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullIntSlice returns the integer slice query parameter value or nil
+// if it is absent. See PullIntSlice for details.
+func (q *Query) PullIntSlice(key string, opt ...[]int) []int {
+	data := q.IntSlice(key, opt...)
+	if !data.Contains {
+		return nil // not default
+	}
+
+	return data.Value
+}
@@ -0,0 +1,181 @@
+package qp
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// Integer is a constraint that permits any signed or unsigned integer
+// type, including named types based on them.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// ParseInteger parses an integer query parameter of any width
+// from the given source.
+//
+// The source is a *url.URL or a *Query. The value is parsed with the bit
+// size and signedness of T, so a value that does not fit the type (e.g.,
+// 300 for uint8 or -1 for uint) is reported as an error instead of
+// silently wrapping around.
+//
+// The optional values have the same meaning as for ParseInt: the first
+// one is the default value, the first two specify the range (min and max)
+// and any additional values are treated as additional valid values.
+//
+// Example Usage:
+//
+//	// Parse an uint16 port with default and range.
+//	// Default: 1024
+//	// Range:   1024-65535
+//	result := ParseInteger[uint16](u, "port", 1024, 65535)
+//
+//	// Parse an int64 timestamp.
+//	result := ParseInteger[int64](u, "ts")
+//
+//	// The type is inferred from the typed default value.
+//	result := ParseInteger(u, "level", uint8(50), 0, 100)
+func ParseInteger[T Integer](src Source, key string, opt ...T) *Result[T] {
+	return integer(query(src), key, opt)
+}
+
+// GetInteger parses an integer query parameter of any width and returns
+// the value and a boolean indicating, true - if a value was passed in
+// query params and successfully parsed.
+//
+// The optional values have the same meaning as for ParseInteger.
+//
+// Example Usage:
+//
+//	// Default: 1024
+//	// Range:   1024-65535
+//	port, ok := GetInteger[uint16](u, "port", 1024, 65535)
+func GetInteger[T Integer](src Source, key string, opt ...T) (T, bool) {
+	data := ParseInteger(src, key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullInteger returns a pointer to the parsed integer query parameter
+// value of any width, or nil if the parameter is absent.
+//
+// If the parameter is specified, but it is empty or invalid, a pointer to
+// the default value is returned. The optional values have the same meaning
+// as for ParseInteger.
+//
+// Example Usage:
+//
+//	ts := PullInteger[int64](u, "ts")
+func PullInteger[T Integer](src Source, key string, opt ...T) *T {
+	data := ParseInteger(src, key, opt...)
+	if !data.Contains {
+		return nil
+	}
+
+	return &data.Value
+}
+
+// ParseIntegerSlice parses an integer slice query parameter of any width
+// from the given source.
+//
+// The function supports query parameters specified as a single string
+// (e.g., "?ids=1,2,3") or as multiple values (e.g., "?ids=1&ids=2&ids=3").
+// Every element is parsed with the bit size and signedness of T.
+//
+// Example Usage:
+//
+//	result := ParseIntegerSlice[uint64](u, "ids")
+func ParseIntegerSlice[T Integer](
+	src Source,
+	key string,
+	opt ...[]T,
+) *Result[[]T] {
+	return integerSlice(query(src), key, opt)
+}
+
+// GetIntegerSlice parses an integer slice query parameter of any width
+// and returns the slice of values and a boolean indicating if the
+// value is valid.
+//
+// Example Usage:
+//
+//	ids, ok := GetIntegerSlice[uint64](u, "ids")
+func GetIntegerSlice[T Integer](
+	src Source,
+	key string,
+	opt ...[]T,
+) ([]T, bool) {
+	data := ParseIntegerSlice(src, key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullIntegerSlice parses an integer slice query parameter of any width
+// and returns the slice of values, or nil if the parameter is absent.
+//
+// Example Usage:
+//
+//	ids := PullIntegerSlice[uint64](u, "ids")
+func PullIntegerSlice[T Integer](src Source, key string, opt ...[]T) []T {
+	data := ParseIntegerSlice(src, key, opt...)
+	if !data.Contains {
+		return nil // not default
+	}
+
+	return data.Value
+}
+
+// integer parses an integer query parameter of the query.
+func integer[T Integer](q *Query, key string, opt []T) *Result[T] {
+	return scalar(q, key, legacySpec(opt), integerParser[T]())
+}
+
+// integerSlice parses an integer slice query parameter of the query.
+func integerSlice[T Integer](q *Query, key string, opt [][]T) *Result[[]T] {
+	return slice(q, key, legacySliceSpec(opt), integerParser[T]())
+}
+
+// The parsers of the integer types, created once.
+var (
+	intParser    = numberParser(parseInteger[int])
+	int8Parser   = numberParser(parseInteger[int8])
+	int16Parser  = numberParser(parseInteger[int16])
+	int32Parser  = numberParser(parseInteger[int32])
+	int64Parser  = numberParser(parseInteger[int64])
+	uintParser   = numberParser(parseInteger[uint])
+	uint8Parser  = numberParser(parseInteger[uint8])
+	uint16Parser = numberParser(parseInteger[uint16])
+	uint32Parser = numberParser(parseInteger[uint32])
+	uint64Parser = numberParser(parseInteger[uint64])
+)
+
+// integerParser returns the parser of the integer type T: one of the
+// parsers above, or a new one for a named type.
+func integerParser[T Integer]() parser[T] {
+	kind := reflect.TypeFor[T]().Kind()
+	if p, ok := builtins[kind].parser.(parser[T]); ok {
+		return p
+	}
+
+	return numberParser(parseInteger[T])
+}
+
+// parseInteger parses a string as an integer with the bit size
+// and signedness of T.
+func parseInteger[T Integer](str string) (T, error) {
+	t := reflect.TypeFor[T]()
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		value, err := strconv.ParseInt(str, 10, t.Bits())
+		return T(value), err
+	}
+
+	value, err := strconv.ParseUint(str, 10, t.Bits())
+	return T(value), err
+}
+
+// kindOf returns the name of the kind of T, e.g. "int" for a named type
+// based on int. It is used to describe the range of the type in errors.
+func kindOf[T any]() string {
+	return reflect.TypeFor[T]().Kind().String()
+}
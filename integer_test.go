@@ -0,0 +1,157 @@
+package qp
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestParseInteger tests the ParseInteger function.
+func TestParseInteger(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		parse func(u *url.URL) any
+		value any
+		err   error
+	}{
+		{
+			name:  "Uint16 in range",
+			query: "port=8081",
+			parse: func(u *url.URL) any {
+				return ParseInteger[uint16](u, "port", 1024, 65535)
+			},
+			value: uint16(8081),
+		},
+		{
+			name:  "Uint16 overflow",
+			query: "port=70000",
+			parse: func(u *url.URL) any {
+				return ParseInteger[uint16](u, "port", 1024, 65535)
+			},
+			value: uint16(1024),
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "Uint8 negative",
+			query: "level=-1",
+			parse: func(u *url.URL) any {
+				return ParseInteger[uint8](u, "level", 50)
+			},
+			value: uint8(50),
+			err:   ErrInvalidSyntax,
+		},
+		{
+			name:  "Int8 out of range",
+			query: "level=-5",
+			parse: func(u *url.URL) any {
+				return ParseInteger(u, "level", int8(0), 0, 10, 100)
+			},
+			value: int8(0),
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "Int8 additional value",
+			query: "level=100",
+			parse: func(u *url.URL) any {
+				return ParseInteger(u, "level", int8(0), 0, 10, 100)
+			},
+			value: int8(100),
+		},
+		{
+			name:  "Int64 timestamp",
+			query: "ts=1700000000123",
+			parse: func(u *url.URL) any {
+				return ParseInteger[int64](u, "ts")
+			},
+			value: int64(1700000000123),
+		},
+		{
+			name:  "Int32 overflow",
+			query: "n=2147483648",
+			parse: func(u *url.URL) any {
+				return ParseInteger[int32](u, "n")
+			},
+			value: int32(0),
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "Uint64 max",
+			query: "n=18446744073709551615",
+			parse: func(u *url.URL) any {
+				return ParseInteger[uint64](u, "n")
+			},
+			value: uint64(18446744073709551615),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+
+			// All results have the Value and Error fields.
+			got := reflect.ValueOf(tc.parse(u)).Elem()
+			value := got.FieldByName("Value").Interface()
+			err, _ := got.FieldByName("Error").Interface().(error)
+
+			if value != tc.value {
+				t.Errorf(".Value: got = %v (%T), want %v (%T)",
+					value, value, tc.value, tc.value)
+			}
+
+			if !errors.Is(err, tc.err) || (err == nil) != (tc.err == nil) {
+				t.Errorf(".Error: got = %v, want %v", err, tc.err)
+			}
+		})
+	}
+}
+
+// TestGetPullInteger tests the GetInteger and PullInteger functions.
+func TestGetPullInteger(t *testing.T) {
+	u, _ := url.Parse("http://example.com?port=80&bad=300")
+	q := New(u)
+
+	if v, ok := GetInteger[uint16](q, "port"); v != 80 || !ok {
+		t.Errorf("GetInteger(): got = %v, %v, want 80, true", v, ok)
+	}
+
+	if v, ok := GetInteger(u, "bad", uint8(1)); v != 1 || ok {
+		t.Errorf("GetInteger(): got = %v, %v, want 1, false", v, ok)
+	}
+
+	if v := PullInteger[int64](u, "absent"); v != nil {
+		t.Errorf("PullInteger(): got = %v, want nil", v)
+	}
+
+	if v := PullInteger(q, "bad", uint8(7)); v == nil || *v != 7 {
+		t.Errorf("PullInteger(): got = %v, want 7", v)
+	}
+}
+
+// TestParseIntegerSlice tests the integer slice functions.
+func TestParseIntegerSlice(t *testing.T) {
+	u, _ := url.Parse("http://example.com?ids=1,2,255&big=1&big=256" +
+		"&neg=-1,-2")
+
+	if v, ok := GetIntegerSlice[uint8](u, "ids"); !ok ||
+		!reflect.DeepEqual(v, []uint8{1, 2, 255}) {
+		t.Errorf("GetIntegerSlice(): got = %v, %v", v, ok)
+	}
+
+	result := ParseIntegerSlice[uint8](u, "big")
+	var e *ParamError
+	if !errors.As(result.Error, &e) || e.Index != 1 ||
+		!errors.Is(e, ErrOutOfRange) {
+		t.Errorf("ParseIntegerSlice(): got error %v", result.Error)
+	}
+
+	if v := PullIntegerSlice[int16](u, "neg"); !reflect.DeepEqual(v,
+		[]int16{-1, -2}) {
+		t.Errorf("PullIntegerSlice(): got = %v", v)
+	}
+
+	if v := PullIntegerSlice[int16](u, "absent", []int16{1}); v != nil {
+		t.Errorf("PullIntegerSlice(): got = %v, want nil", v)
+	}
+}
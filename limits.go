@@ -0,0 +1,253 @@
+package qp
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Limits restricts the size of the input, so that a handler cannot be
+// made to allocate without bound by a query like "?ids=1,1,1,..." with
+// a million elements.
+//
+// A zero field inherits the limit of the Query, which inherits the
+// default limits set with SetDefaultLimits; a negative field removes
+// the limit. The default limits are zero, i.e. there are no limits
+// unless they are set.
+//
+// The limits are checked before the values are split or parsed, and an
+// exceeded limit is reported as ErrTooLarge.
+type Limits struct {
+	// MaxValueLength is the maximum length in bytes of a raw value,
+	// e.g. of "1,2,3" in "?ids=1,2,3".
+	MaxValueLength int
+
+	// MaxElements is the maximum number of elements of a slice,
+	// counted in all values of the parameter.
+	MaxElements int
+
+	// MaxKeys is the maximum number of distinct keys of the query.
+	MaxKeys int
+
+	// MaxQueryLength is the maximum length in bytes of the raw query of
+	// a URL. A query longer than the default limit is not parsed at all
+	// by New, Bind and the package-level functions, so a greater limit
+	// of a Query or a call cannot lift it. It does not apply to values
+	// given with FromValues and BindValues, which have no raw query.
+	MaxQueryLength int
+}
+
+var (
+	limitsMu      sync.RWMutex
+	defaultLimits Limits
+)
+
+// SetDefaultLimits sets the limits of all queries and calls that do not
+// set their own, see Limits. It is typically done once at startup,
+// SetDefaultLimits is safe for concurrent use.
+//
+// Example Usage:
+//
+//	func init() {
+//	    qp.SetDefaultLimits(qp.Limits{
+//	        MaxValueLength: 4096,
+//	        MaxElements:    1000,
+//	        MaxKeys:        100,
+//	        MaxQueryLength: 8192,
+//	    })
+//	}
+func SetDefaultLimits(l Limits) {
+	limitsMu.Lock()
+	defer limitsMu.Unlock()
+	defaultLimits = l
+}
+
+// DefaultLimits returns the limits set with SetDefaultLimits.
+func DefaultLimits() Limits {
+	limitsMu.RLock()
+	defer limitsMu.RUnlock()
+	return defaultLimits
+}
+
+// Limit sets the limits of the call, overriding the limits of the Query
+// and the default limits field by field, see Limits.
+//
+// Example Usage:
+//
+//	// ?ids=1,2,3 with at most 500 elements.
+//	ids := qp.IntSlice(u, "ids", qp.Limit(qp.Limits{MaxElements: 500}))
+//	if errors.Is(ids.Error, qp.ErrTooLarge) {
+//	    http.Error(w, ids.Error.Error(), http.StatusRequestEntityTooLarge)
+//	}
+func Limit(l Limits) Option {
+	return func(o *options) {
+		o.limits = o.limits.merge(l)
+	}
+}
+
+// WithLimits returns a copy of the Query that applies the limits to all
+// calls, unless the Limit option of a call overrides them. The copy
+// shares the values with the Query.
+//
+// Example Usage:
+//
+//	q := qp.New(r.URL).WithLimits(qp.Limits{MaxKeys: 20})
+//	limit := q.Int("limit", 20, 1, 100) // ErrTooLarge for 21 keys
+func (q *Query) WithLimits(l Limits) *Query {
+	c := *q
+	c.limits = l
+	return &c
+}
+
+// merge returns the limits with the non-zero fields of other.
+func (l Limits) merge(other Limits) Limits {
+	if other.MaxValueLength != 0 {
+		l.MaxValueLength = other.MaxValueLength
+	}
+
+	if other.MaxElements != 0 {
+		l.MaxElements = other.MaxElements
+	}
+
+	if other.MaxKeys != 0 {
+		l.MaxKeys = other.MaxKeys
+	}
+
+	if other.MaxQueryLength != 0 {
+		l.MaxQueryLength = other.MaxQueryLength
+	}
+
+	return l
+}
+
+// exceeds reports whether n exceeds the limit, a limit that is not
+// positive is no limit.
+func exceeds(n, limit int) bool {
+	return limit > 0 && n > limit
+}
+
+// limitsFor returns the limits in effect for a call with the limits.
+func (q *Query) limitsFor(l Limits) Limits {
+	return DefaultLimits().merge(q.limits).merge(l)
+}
+
+// oversize checks the size of the whole query against the limits. If a
+// limit is exceeded, it returns the size and the violated constraint,
+// e.g. "120 keys" and "max 100 keys", otherwise two empty strings.
+func (q *Query) oversize(l Limits) (string, string) {
+	switch {
+	case q.skipped > 0:
+		return fmt.Sprintf("%d bytes", q.size),
+			fmt.Sprintf("max query length %d", q.skipped)
+	case exceeds(q.size, l.MaxQueryLength):
+		return fmt.Sprintf("%d bytes", q.size),
+			fmt.Sprintf("max query length %d", l.MaxQueryLength)
+	case exceeds(len(q.values), l.MaxKeys):
+		return fmt.Sprintf("%d keys", len(q.values)),
+			fmt.Sprintf("max %d keys", l.MaxKeys)
+	}
+
+	return "", ""
+}
+
+// checkSize checks the size of the query and the lengths of the raw
+// values of the key against the limits.
+func (q *Query) checkSize(key string, data []string, l Limits) error {
+	if size, constraint := q.oversize(l); constraint != "" {
+		return newParamError(ErrTooLarge, key, size, constraint)
+	}
+
+	for _, str := range data {
+		if exceeds(len(str), l.MaxValueLength) {
+			return newParamError(ErrTooLarge, key,
+				fmt.Sprintf("%d bytes", len(str)),
+				fmt.Sprintf("max value length %d", l.MaxValueLength))
+		}
+	}
+
+	return nil
+}
+
+// checkQuery checks the size of the whole query against the limits of
+// the Query, for Bind.
+func (q *Query) checkQuery() error {
+	size, constraint := q.oversize(q.limitsFor(Limits{}))
+	if constraint == "" {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s (%s)", ErrTooLarge, size, constraint)
+}
+
+// checkElements checks the number of elements of a slice against the
+// limits. The elements are counted without splitting the values.
+func (s *spec[T]) checkElements(
+	p parser[T],
+	key string,
+	data []string,
+	l Limits,
+) error {
+	if l.MaxElements <= 0 {
+		return nil
+	}
+
+	n := len(data)
+	if s.splitMode(p, data) != SplitNone {
+		sep := cmp.Or(s.sep, ",")
+		n = 0
+		for _, str := range data {
+			if s.quoted {
+				n += countQuoted(str, sep)
+			} else {
+				n += strings.Count(str, sep) + 1
+			}
+		}
+	}
+
+	if n > l.MaxElements {
+		return newParamError(ErrTooLarge, key,
+			fmt.Sprintf("%d elements", n),
+			fmt.Sprintf("max %d elements", l.MaxElements))
+	}
+
+	return nil
+}
+
+// countQuoted counts the items of the string split by splitQuoted
+// without splitting it. It follows the same rules: only a quote at the
+// start of an item opens a quoted item, and a malformed quote ends the
+// last item.
+func countQuoted(str, sep string) int {
+	n := 1
+	for {
+		if !strings.HasPrefix(str, `"`) {
+			i := strings.Index(str, sep)
+			if i < 0 {
+				return n
+			}
+			str, n = str[i+len(sep):], n+1
+			continue
+		}
+
+		// A quoted item ends at a single quote, "" is an escaped quote.
+		i := 1
+		for {
+			j := strings.IndexByte(str[i:], '"')
+			if j < 0 {
+				return n
+			}
+
+			i += j + 1
+			if !strings.HasPrefix(str[i:], `"`) {
+				break
+			}
+			i++
+		}
+
+		if !strings.HasPrefix(str[i:], sep) {
+			return n // the end of the string or a malformed quote
+		}
+		str, n = str[i+len(sep):], n+1
+	}
+}
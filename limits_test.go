@@ -0,0 +1,263 @@
+package qp
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestLimit tests the Limit option and the limits of a Query.
+func TestLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		parse      func(q *Query) (any, error)
+		value      any
+		err        error
+		raw        string
+		constraint string
+	}{
+		{
+			name:  "Elements",
+			query: "ids=1,2,3",
+			parse: func(q *Query) (any, error) {
+				r := IntSlice(q, "ids", Limit(Limits{MaxElements: 2}))
+				return r.Value, r.Error
+			},
+			value:      []int{},
+			err:        ErrTooLarge,
+			raw:        "3 elements",
+			constraint: "max 2 elements",
+		},
+		{
+			name:  "Elements of repeated values",
+			query: "ids=1,2&ids=3",
+			parse: func(q *Query) (any, error) {
+				r := IntSlice(q, "ids", Limit(Limits{MaxElements: 2}))
+				return r.Value, r.Error
+			},
+			value:      []int{},
+			err:        ErrTooLarge,
+			raw:        "3 elements",
+			constraint: "max 2 elements",
+		},
+		{
+			name:  "Elements within the limit",
+			query: "ids=1,2",
+			parse: func(q *Query) (any, error) {
+				r := IntSlice(q, "ids", Limit(Limits{MaxElements: 2}))
+				return r.Value, r.Error
+			},
+			value: []int{1, 2},
+		},
+		{
+			name:  "Elements not split",
+			query: "names=a,b&names=c",
+			parse: func(q *Query) (any, error) {
+				r := StringSlice(q, "names", Limit(Limits{MaxElements: 2}))
+				return r.Value, r.Error
+			},
+			value: []string{"a,b", "c"},
+		},
+		{
+			name:  "Quoted elements",
+			query: `names="Doe,+John",Smith`,
+			parse: func(q *Query) (any, error) {
+				r := StringSlice(q, "names", Quoted(),
+					Limit(Limits{MaxElements: 2}))
+				return r.Value, r.Error
+			},
+			value: []string{"Doe, John", "Smith"},
+		},
+		{
+			name:  "Quote inside an element",
+			query: `names=a"b,x,x,x`,
+			parse: func(q *Query) (any, error) {
+				r := StringSlice(q, "names", Quoted(),
+					Limit(Limits{MaxElements: 3}))
+				return r.Value, r.Error
+			},
+			value:      []string{},
+			err:        ErrTooLarge,
+			raw:        "4 elements",
+			constraint: "max 3 elements",
+		},
+		{
+			name:  "Value length",
+			query: "q=abcdef",
+			parse: func(q *Query) (any, error) {
+				r := String(q, "q", Default("x"),
+					Limit(Limits{MaxValueLength: 5}))
+				return r.Value, r.Error
+			},
+			value:      "x",
+			err:        ErrTooLarge,
+			raw:        "6 bytes",
+			constraint: "max value length 5",
+		},
+		{
+			name:  "Value length of a slice",
+			query: "ids=1,2&ids=1234",
+			parse: func(q *Query) (any, error) {
+				r := IntSlice(q, "ids", Limit(Limits{MaxValueLength: 3}))
+				return r.Value, r.Error
+			},
+			value:      []int{},
+			err:        ErrTooLarge,
+			raw:        "4 bytes",
+			constraint: "max value length 3",
+		},
+		{
+			name:  "Keys",
+			query: "a=1&b=2&c=3",
+			parse: func(q *Query) (any, error) {
+				r := Int(q, "a", Limit(Limits{MaxKeys: 2}))
+				return r.Value, r.Error
+			},
+			value:      0,
+			err:        ErrTooLarge,
+			raw:        "3 keys",
+			constraint: "max 2 keys",
+		},
+		{
+			name:  "Keys for an absent key",
+			query: "a=1&b=2&c=3",
+			parse: func(q *Query) (any, error) {
+				r := Int(q, "x", Limit(Limits{MaxKeys: 2}))
+				return r.Value, r.Error
+			},
+			value:      0,
+			err:        ErrTooLarge,
+			raw:        "3 keys",
+			constraint: "max 2 keys",
+		},
+		{
+			name:  "Query length",
+			query: "q=abcdef",
+			parse: func(q *Query) (any, error) {
+				r := String(q, "q", Limit(Limits{MaxQueryLength: 5}))
+				return r.Value, r.Error
+			},
+			value:      "",
+			err:        ErrTooLarge,
+			raw:        "8 bytes",
+			constraint: "max query length 5",
+		},
+		{
+			name:  "Limits of the query",
+			query: "ids=1,2,3",
+			parse: func(q *Query) (any, error) {
+				r := q.WithLimits(Limits{MaxElements: 2}).IntSlice("ids")
+				return r.Value, r.Error
+			},
+			value:      []int{},
+			err:        ErrTooLarge,
+			raw:        "3 elements",
+			constraint: "max 2 elements",
+		},
+		{
+			name:  "Limits of the query removed",
+			query: "ids=1,2,3",
+			parse: func(q *Query) (any, error) {
+				r := IntSlice(q.WithLimits(Limits{MaxElements: 2}), "ids",
+					Limit(Limits{MaxElements: -1}))
+				return r.Value, r.Error
+			},
+			value: []int{1, 2, 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			value, err := tc.parse(New(u))
+
+			if !reflect.DeepEqual(value, tc.value) {
+				t.Errorf(".Value: got = %v, want %v", value, tc.value)
+			}
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf(".Error: got = %v, want %v", err, tc.err)
+			}
+
+			var e *ParamError
+			if errors.As(err, &e) && (e.Raw != tc.raw ||
+				e.Constraint != tc.constraint) {
+				t.Errorf(".Raw, .Constraint: got = %q, %q, want %q, %q",
+					e.Raw, e.Constraint, tc.raw, tc.constraint)
+			}
+		})
+	}
+}
+
+// TestSetDefaultLimits tests the default limits.
+func TestSetDefaultLimits(t *testing.T) {
+	t.Cleanup(func() { SetDefaultLimits(Limits{}) })
+	SetDefaultLimits(Limits{MaxElements: 3, MaxQueryLength: 32})
+
+	u, _ := url.Parse("http://example.com?ids=1,2,3,4")
+	if r := ParseIntSlice(u, "ids"); !errors.Is(r.Error, ErrTooLarge) {
+		t.Errorf("ParseIntSlice() .Error: got = %v, want %v", r.Error,
+			ErrTooLarge)
+	}
+
+	r := IntSlice(u, "ids", Limit(Limits{MaxElements: 4}))
+	if r.Error != nil || len(r.Value) != 4 {
+		t.Errorf("IntSlice(): got = %v, %v", r.Value, r.Error)
+	}
+
+	// The query is too long to be parsed.
+	u, _ = url.Parse("http://example.com?q=" + strings.Repeat("a", 40))
+	r2 := String(u, "q", Limit(Limits{MaxQueryLength: 100}))
+	expected := "input too large for key q: 42 bytes (max query length 32)"
+	if r2.Error == nil || r2.Error.Error() != expected {
+		t.Errorf("String() .Error: got = %v, want %s", r2.Error, expected)
+	}
+
+	var dst struct {
+		Q string `qp:"q"`
+	}
+	if err := Bind(u, &dst); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Bind() error: got = %v, want %v", err, ErrTooLarge)
+	}
+
+	// Values without a raw query have no query length.
+	if err := BindValues(u.Query(), &dst); err != nil || len(dst.Q) != 40 {
+		t.Errorf("BindValues(): got = %q, %v", dst.Q, err)
+	}
+
+	if l := DefaultLimits(); l.MaxElements != 3 {
+		t.Errorf("DefaultLimits(): got = %+v", l)
+	}
+}
+
+// TestCountQuoted tests that countQuoted counts the items of
+// splitQuoted.
+func TestCountQuoted(t *testing.T) {
+	tests := []string{
+		"",
+		"a",
+		"a,b,c",
+		`"a,b",c`,
+		`"a ""b"", c",d,"e"`,
+		`a,"b,c`,
+		`,,`,
+		`a"b,x,x,x`,
+		`a"b",c,"d`,
+		`"a"b,c`,
+		`"a",`,
+		`""",",x`,
+	}
+
+	for _, str := range tests {
+		t.Run(str, func(t *testing.T) {
+			items, _ := splitQuoted(str, ",")
+			if n := countQuoted(str, ","); n != len(items) {
+				t.Errorf("countQuoted(%q): got = %d, want %d", str, n,
+					len(items))
+			}
+		})
+	}
+}
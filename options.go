@@ -0,0 +1,661 @@
+package qp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Option configures how a query parameter is parsed and validated by
+// the functions that accept options, e.g. Int, String or DurationSlice.
+//
+// Options are not bound to a type. The values given to Default, Min, Max
+// and OneOf are converted to the type of the parameter: a value of that
+// type is used as is, any other value is formatted with fmt.Sprint and
+// parsed like a query value. So qp.Default(18) works for both Int and
+// Float, and qp.Max("1h") works for Duration. An option that cannot be
+// applied is reported as ErrInvalidOption in the Error field of the
+// result.
+//
+// Example Usage:
+//
+//	// Default: 18
+//	// Range:   18-65
+//	// Additional: 70, 99
+//	age := qp.Int(u, "age", qp.Default(18), qp.Min(18), qp.Max(65),
+//	    qp.OneOf(70, 99))
+//
+//	// Default and valid values without a range.
+//	size := qp.Int(u, "size", qp.Default(10), qp.OneOf(10, 20, 50))
+//
+//	// One-sided range.
+//	offset := qp.Int(u, "offset", qp.Min(0))
+type Option func(*options)
+
+// options holds the options before they are converted to the type
+// of the parameter.
+type options struct {
+	def        any
+	min        any
+	max        any
+	hasDef     bool
+	hasMin     bool
+	hasMax     bool
+	oneOf      []any
+	required   bool
+	duplicates DuplicatePolicy
+	outOfRange RangePolicy
+	layouts    []string
+	location   *time.Location
+
+	minExclusive bool
+	maxExclusive bool
+	step         any
+	hasStep      bool
+
+	minItems  int
+	maxItems  int
+	maxLength int
+	unique    bool
+
+	split  SplitMode
+	sep    string
+	hasSep bool
+	quoted bool
+
+	aliases    map[string]string
+	ignoreCase bool
+
+	minRunes   int
+	maxRunes   int
+	pattern    string
+	hasPattern bool
+	chars      string
+	tables     []*unicode.RangeTable
+	hasCharset bool
+	trim       bool
+	normalize  func(string) string
+
+	limits Limits
+}
+
+// Default sets the value used if the query parameter is absent, empty
+// or invalid. For slices the value is a slice, e.g. []int{1, 2}.
+func Default(value any) Option {
+	return func(o *options) {
+		o.def, o.hasDef = value, true
+	}
+}
+
+// Min sets the minimum valid value, inclusive. For slices it applies
+// to every element. Min is supported for numbers, durations and times.
+func Min(value any) Option {
+	return func(o *options) {
+		o.min, o.hasMin, o.minExclusive = value, true, false
+	}
+}
+
+// Max sets the maximum valid value, inclusive. For slices it applies
+// to every element. Max is supported for numbers, durations and times.
+func Max(value any) Option {
+	return func(o *options) {
+		o.max, o.hasMax, o.maxExclusive = value, true, false
+	}
+}
+
+// ExclusiveMin sets the minimum of the range like Min, but the value
+// itself is not valid, e.g. ExclusiveMin(0) for "price > 0". It replaces
+// Min and cannot be combined with RangeClamp.
+//
+// Example Usage:
+//
+//	// Range: (0, 1000]
+//	price := qp.Float(u, "price", qp.ExclusiveMin(0), qp.Max(1000))
+func ExclusiveMin(value any) Option {
+	return func(o *options) {
+		o.min, o.hasMin, o.minExclusive = value, true, true
+	}
+}
+
+// ExclusiveMax sets the maximum of the range like Max, but the value
+// itself is not valid. It replaces Max and cannot be combined with
+// RangeClamp.
+func ExclusiveMax(value any) Option {
+	return func(o *options) {
+		o.max, o.hasMax, o.maxExclusive = value, true, true
+	}
+}
+
+// MultipleOf requires the value to be a multiple of the positive step,
+// otherwise the error is ErrNotMultiple. For slices it applies to every
+// element. Floats are checked with a tolerance of their precision, so
+// 0.3 is a multiple of 0.1. MultipleOf is supported for numbers and
+// durations, the values given with OneOf are valid regardless of it. It
+// cannot be combined with RangeClamp, since a clamped value may not be
+// a multiple of the step.
+//
+// Example Usage:
+//
+//	// 10, 20, ... 100
+//	limit := qp.Int(u, "limit", qp.Default(20), qp.Min(10), qp.Max(100),
+//	    qp.MultipleOf(10))
+func MultipleOf(step any) Option {
+	return func(o *options) {
+		o.step, o.hasStep = step, true
+	}
+}
+
+// OneOf sets the valid values. If a range is set with Min or Max, the
+// values are valid in addition to the range, otherwise they are the only
+// valid values. For slices it applies to every element.
+func OneOf(values ...any) Option {
+	return func(o *options) {
+		o.oneOf = append(o.oneOf, values...)
+	}
+}
+
+// MinItems requires a slice to have at least n items, otherwise the
+// error is ErrItemCount. An absent or empty parameter has no items and
+// is only rejected if Required is set.
+func MinItems(n int) Option {
+	return func(o *options) {
+		o.minItems = n
+	}
+}
+
+// MaxItems allows at most n items in a slice, otherwise the error is
+// ErrItemCount. The count is checked before the items are parsed.
+//
+// Example Usage:
+//
+//	// ?ids=1,2,3
+//	ids := qp.IntSlice(u, "ids", qp.MinItems(1), qp.MaxItems(100),
+//	    qp.Unique())
+func MaxItems(n int) Option {
+	return func(o *options) {
+		o.maxItems = n
+	}
+}
+
+// Unique requires the elements of a slice to be distinct, otherwise the
+// error is ErrNotUnique and its Index is the index of the repeated
+// element. Elements are equal if their parsed values are, so "1" and
+// "01" are the same integer.
+func Unique() Option {
+	return func(o *options) {
+		o.unique = true
+	}
+}
+
+// MaxTotalLength limits the total length of the raw elements of a slice
+// to n characters (runes), not counting the separators. Longer values
+// are reported as ErrTooLong before the elements are parsed.
+//
+// Example Usage:
+//
+//	// Up to 10 tags of 200 characters in total.
+//	tags := qp.StringSlice(u, "tags", qp.MaxItems(10),
+//	    qp.MaxTotalLength(200))
+func MaxTotalLength(n int) Option {
+	return func(o *options) {
+		o.maxLength = n
+	}
+}
+
+// SplitMode specifies which values of a slice parameter are split into
+// elements at the separator, see Split.
+type SplitMode int
+
+const (
+	// SplitAuto splits a single value, e.g. "?ids=1,2,3", and every value
+	// of numbers and booleans, which cannot contain the separator, e.g.
+	// "?ids=1,2&ids=3". Repeated values of other types are elements as
+	// they are, e.g. "?names=Doe, John&names=Smith". It is the default.
+	SplitAuto SplitMode = iota
+
+	// SplitEach splits every value, e.g. "?names=a,b&names=c" is
+	// [a b c].
+	SplitEach
+
+	// SplitNone never splits, the elements are the repeated values only,
+	// e.g. "?names=Doe, John&names=Smith".
+	SplitNone
+)
+
+// Split sets which values of a slice are split at the separator, see
+// SplitMode.
+//
+// Example Usage:
+//
+//	// ?name=Doe, John&name=Smith
+//	names := qp.StringSlice(u, "name", qp.Split(qp.SplitNone))
+func Split(mode SplitMode) Option {
+	return func(o *options) {
+		o.split = mode
+	}
+}
+
+// Separator sets the separator of slice elements, a comma by default.
+// It may be any non-empty string, e.g. "|", ";" or " ". A semicolon must
+// be percent-encoded in the URL ("%3B"), since net/url drops the query
+// parameters that contain a raw one.
+//
+// Example Usage:
+//
+//	// ?prices=1,5|2,25
+//	prices := qp.StringSlice(u, "prices", qp.Separator("|"))
+func Separator(sep string) Option {
+	return func(o *options) {
+		o.sep, o.hasSep = sep, true
+	}
+}
+
+// Quoted allows CSV-style quoting of slice elements: an element enclosed
+// in double quotes may contain the separator, and a quote inside it is
+// written twice. A quote in the middle of an unquoted element is taken
+// as is. An unterminated quote, or text after the closing quote, is
+// reported as ErrInvalidSyntax.
+//
+// Example Usage:
+//
+//	// ?names="Doe, John",Smith,"say ""hi"""
+//	// [Doe, John  Smith  say "hi"]
+//	names := qp.StringSlice(u, "names", qp.Quoted())
+func Quoted() Option {
+	return func(o *options) {
+		o.quoted = true
+	}
+}
+
+// IgnoreCase matches the values given with OneOf, and the aliases given
+// with Aliases, case-insensitively. A matching value is replaced with its
+// canonical spelling from OneOf, so "?status=OPEN" is "open". For slices
+// it applies to every element.
+//
+// Example Usage:
+//
+//	// ?status=Open,CLOSED - [open closed]
+//	status := qp.StringSlice(u, "status", qp.OneOf("open", "closed"),
+//	    qp.IgnoreCase())
+func IgnoreCase() Option {
+	return func(o *options) {
+		o.ignoreCase = true
+	}
+}
+
+// Aliases maps alternative spellings to values, which are converted to
+// the type of the parameter like the values of OneOf. An alias is
+// replaced before the value is validated, so its value must be valid.
+// For slices it applies to every element. Repeated Aliases options are
+// merged.
+//
+// Example Usage:
+//
+//	// ?status=wip - in_progress
+//	status := qp.String(u, "status",
+//	    qp.OneOf("open", "in_progress", "closed"),
+//	    qp.Aliases(map[string]string{"wip": "in_progress"}))
+func Aliases(aliases map[string]string) Option {
+	return func(o *options) {
+		if o.aliases == nil {
+			o.aliases = make(map[string]string, len(aliases))
+		}
+		for k, v := range aliases {
+			o.aliases[k] = v
+		}
+	}
+}
+
+// MinLength requires the value to have at least n characters (runes),
+// otherwise the error is ErrTooShort. For slices it applies to every
+// element.
+//
+// The string constraints MinLength, MaxLength, Pattern and Charset check
+// the raw value after Trim, NFC and Normalize, before it is parsed. They
+// are meant for strings, but work for any type.
+func MinLength(n int) Option {
+	return func(o *options) {
+		o.minRunes = n
+	}
+}
+
+// MaxLength allows at most n characters (runes) in the value, otherwise
+// the error is ErrTooLong. For slices it applies to every element, see
+// MaxTotalLength for the slice as a whole.
+//
+// Example Usage:
+//
+//	// Default: "", Length: 3-20
+//	username := qp.String(u, "username", qp.Trim(), qp.MinLength(3),
+//	    qp.MaxLength(20))
+func MaxLength(n int) Option {
+	return func(o *options) {
+		o.maxRunes = n
+	}
+}
+
+// Pattern requires the value to match the regular expression in the
+// syntax of the regexp package, otherwise the error is ErrPattern. The
+// expression is not anchored, use ^ and $ to match the whole value. An
+// invalid expression is reported as ErrInvalidOption.
+//
+// Example Usage:
+//
+//	slug := qp.String(u, "slug", qp.Pattern(`^[a-z0-9]+(-[a-z0-9]+)*$`))
+func Pattern(expr string) Option {
+	return func(o *options) {
+		o.pattern, o.hasPattern = expr, true
+	}
+}
+
+// Charset allows only the characters listed in chars and the characters
+// of the Unicode classes, e.g. unicode.Letter or unicode.Digit. Any other
+// character is reported as ErrCharset, the Constraint of the error names
+// the character.
+//
+// Example Usage:
+//
+//	// Letters, digits, '-' and '_'.
+//	name := qp.String(u, "name",
+//	    qp.Charset("-_", unicode.Letter, unicode.Digit))
+func Charset(chars string, classes ...*unicode.RangeTable) Option {
+	return func(o *options) {
+		o.chars, o.tables, o.hasCharset = chars, classes, true
+	}
+}
+
+// Trim removes leading and trailing white space from the value, or from
+// every element of a slice, before it is validated and parsed. A value
+// of white space only is empty.
+func Trim() Option {
+	return func(o *options) {
+		o.trim = true
+	}
+}
+
+// Normalize transforms the value, or every element of a slice, after Trim
+// and before it is validated and parsed, e.g. with strings.ToLower. It
+// replaces NFC and is replaced by it.
+//
+// Example Usage:
+//
+//	tag := qp.String(u, "tag", qp.Trim(), qp.Normalize(strings.ToLower))
+func Normalize(fn func(string) string) Option {
+	return func(o *options) {
+		o.normalize = fn
+	}
+}
+
+// NFC normalizes the value, or every element of a slice, to the Unicode
+// Normalization Form C after Trim, so that "e\u0301" and "\u00e9" are the
+// same value and MaxLength counts them alike. It is Normalize with
+// norm.NFC.String of golang.org/x/text/unicode/norm.
+//
+// Example Usage:
+//
+//	// "e\u0301" and "\u00e9" are the same search term.
+//	q := qp.String(u, "q", qp.Trim(), qp.NFC(), qp.MaxLength(100))
+func NFC() Option {
+	return Normalize(norm.NFC.String)
+}
+
+// Required makes the parameter mandatory: if it is absent, the Error
+// field of the result is ErrRequired, if it is empty (e.g. "?id="),
+// ErrEmpty. The default value, if any, is still returned as the value.
+//
+// Example Usage:
+//
+//	id := qp.Int(u, "id", qp.Required())
+//	if errors.Is(id.Error, qp.ErrRequired) {
+//	    // ...
+//	}
+func Required() Option {
+	return func(o *options) {
+		o.required = true
+	}
+}
+
+// Duplicates sets the policy for a repeated parameter, e.g.
+// "?limit=10&limit=1000", overriding the policy of the Query. The Count
+// field of the result holds the number of values, so a repeated
+// parameter can be logged whatever the policy is. It has no effect on
+// slices, which use all values.
+//
+// Example Usage:
+//
+//	limit := qp.Int(u, "limit", qp.Max(100),
+//	    qp.Duplicates(qp.DuplicateReject))
+//	if limit.Count > 1 {
+//	    log.Printf("repeated limit: %v", r.URL.Query()["limit"])
+//	}
+func Duplicates(policy DuplicatePolicy) Option {
+	return func(o *options) {
+		o.duplicates = policy
+	}
+}
+
+// RangePolicy specifies what happens to a value outside the range set
+// with Min and Max, see OutOfRange.
+type RangePolicy int
+
+const (
+	// RangeError reports the value as ErrOutOfRange and uses the default
+	// value. It is the default policy.
+	RangeError RangePolicy = iota
+
+	// RangeDefault uses the default value without an error.
+	RangeDefault
+
+	// RangeClamp uses the nearest bound of the range, e.g. Max for
+	// "?limit=5000" with the range 1-100.
+	RangeClamp
+)
+
+// OutOfRange sets the policy for values outside the range. With
+// RangeDefault and RangeClamp the Adjusted field of the result is set
+// instead of the Error field, so the client can still be warned. For
+// slices, RangeClamp clamps every element and RangeDefault replaces the
+// whole slice with the default value.
+//
+// The policy applies to the range only: values that are not valid for
+// the type (e.g. 300 for uint8) or not one of the valid values without
+// a range are still reported as errors.
+//
+// Example Usage:
+//
+//	// ?limit=5000 gives 100.
+//	limit := qp.Int(u, "limit", qp.Default(20), qp.Min(1), qp.Max(100),
+//	    qp.OutOfRange(qp.RangeClamp))
+//	if limit.Adjusted {
+//	    w.Header().Set("Warning", `199 - "limit adjusted"`)
+//	}
+func OutOfRange(policy RangePolicy) Option {
+	return func(o *options) {
+		o.outOfRange = policy
+	}
+}
+
+// Layouts sets the layouts of a time parameter, see ParseTime.
+// It is supported only by Time and TimeSlice.
+func Layouts(layouts ...string) Option {
+	return func(o *options) {
+		o.layouts = append(o.layouts, layouts...)
+	}
+}
+
+// Location sets the location of time values without time zone
+// information, see TimeFormat. It is supported only by Time and
+// TimeSlice.
+func Location(loc *time.Location) Option {
+	return func(o *options) {
+		o.location = loc
+	}
+}
+
+// newOptions applies the options.
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+
+	return o
+}
+
+// resolve converts the options to the type of the parameter with the
+// parser. If slice is true, the default value is a slice. Errors are
+// stored in the spec and reported when the parameter is parsed.
+func resolve[T any](o options, p parser[T], slice bool) spec[T] {
+	var (
+		s    spec[T]
+		err  error
+		errs []error
+	)
+
+	s.required, s.duplicates = o.required, o.duplicates
+	s.outOfRange = o.outOfRange
+	s.minExclusive, s.maxExclusive = o.minExclusive, o.maxExclusive
+	s.minItems, s.maxItems = o.minItems, o.maxItems
+	s.maxLength, s.unique = o.maxLength, o.unique
+	s.split, s.sep, s.quoted = o.split, o.sep, o.quoted
+	s.ignoreCase = o.ignoreCase
+	s.minRunes, s.maxRunes = o.minRunes, o.maxRunes
+	s.trim, s.normalize = o.trim, o.normalize
+	s.limits = o.limits
+
+	if o.hasDef {
+		s.hasDef = true
+		if slice {
+			s.defs, err = convertSlice(o.def, p)
+			if err != nil {
+				s.defs = []T{} // not nil
+			}
+		} else {
+			s.def, err = convertValue(o.def, p)
+		}
+		errs = append(errs, optionError("Default", o.def, err))
+	}
+
+	if o.hasMin {
+		s.min, err = convertValue(o.min, p)
+		s.hasMin = true
+		errs = append(errs, optionError("Min", o.min, err))
+	}
+
+	if o.hasMax {
+		s.max, err = convertValue(o.max, p)
+		s.hasMax = true
+		errs = append(errs, optionError("Max", o.max, err))
+	}
+
+	if o.hasStep {
+		s.step, err = convertValue(o.step, p)
+		s.hasStep = true
+		errs = append(errs, optionError("MultipleOf", o.step, err))
+	}
+
+	if o.hasPattern {
+		s.pattern, err = regexp.Compile(o.pattern)
+		errs = append(errs, optionError("Pattern", o.pattern, err))
+	}
+
+	if o.hasCharset {
+		s.charset = charset(o.chars, o.tables)
+	}
+
+	if len(o.aliases) != 0 {
+		s.aliases = make(map[string]T, len(o.aliases))
+	}
+
+	for _, k := range sortedKeys(o.aliases) {
+		s.aliases[k], err = convertValue(o.aliases[k], p)
+		errs = append(errs, optionError("Aliases", k+": "+o.aliases[k],
+			err))
+	}
+
+	for _, v := range o.oneOf {
+		value, err := convertValue(v, p)
+		s.oneOf = append(s.oneOf, value)
+		errs = append(errs, optionError("OneOf", v, err))
+	}
+
+	if !slice && s.sliceOnly() {
+		errs = append(errs, errSliceOnly)
+	} else if o.hasSep && o.sep == "" {
+		errs = append(errs, errors.New("Separator must not be empty"))
+	}
+
+	if _, ok := any(s.def).(time.Time); !ok &&
+		(o.layouts != nil || o.location != nil) {
+		errs = append(errs, fmt.Errorf("Layouts and Location are not "+
+			"supported for %s", kindOf[T]()))
+	}
+
+	s.err = errors.Join(errs...)
+	return s
+}
+
+// convertValue converts the option value to T, see Option.
+func convertValue[T any](value any, p parser[T]) (T, error) {
+	// The parsers of interface types (see textParser) always parse,
+	// since any value is of such type.
+	dynamic := reflect.TypeFor[T]().Kind() == reflect.Interface
+	if v, ok := value.(T); ok && !dynamic {
+		return v, nil
+	}
+
+	return p.parse(fmt.Sprint(value))
+}
+
+// convertSlice converts the slice option value to []T, see Option.
+func convertSlice[T any](value any, p parser[T]) ([]T, error) {
+	dynamic := reflect.TypeFor[T]().Kind() == reflect.Interface
+	if v, ok := value.([]T); ok && !dynamic {
+		return v, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice {
+		return nil, errors.New("not a slice")
+	}
+
+	result := make([]T, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		v, err := convertValue(rv.Index(i).Interface(), p)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+
+	return result, nil
+}
+
+// sortedKeys returns the keys of the map in order, so the errors of
+// the options are reported in a stable order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// optionError describes the error of the option, or returns nil.
+func optionError(name string, value any, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%s(%v): %w", name, value, err)
+}
@@ -0,0 +1,1690 @@
+package qp
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+)
+
+// TestOptions tests the functions with options.
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		parse func(u *url.URL) (any, error)
+		value any
+		err   error
+	}{
+		{
+			name:  "Int in range",
+			query: "age=30",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "age", Default(18), Min(18), Max(65), OneOf(70))
+				return r.Value, r.Error
+			},
+			value: 30,
+		},
+		{
+			name:  "Int additional value",
+			query: "age=70",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "age", Default(18), Min(18), Max(65), OneOf(70))
+				return r.Value, r.Error
+			},
+			value: 70,
+		},
+		{
+			name:  "Int out of range",
+			query: "age=66",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "age", Default(18), Min(18), Max(65), OneOf(70))
+				return r.Value, r.Error
+			},
+			value: 18,
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "Default and valid values only",
+			query: "size=30",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "size", Default(10), OneOf(10, 20, 50))
+				return r.Value, r.Error
+			},
+			value: 10,
+			err:   ErrNotAllowed,
+		},
+		{
+			name:  "Min only",
+			query: "offset=1000000",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "offset", Min(0))
+				return r.Value, r.Error
+			},
+			value: 1000000,
+		},
+		{
+			name:  "Min only violated",
+			query: "offset=-1",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "offset", Min(0))
+				return r.Value, r.Error
+			},
+			value: 0,
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "Max only violated",
+			query: "limit=101",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "limit", Default(20), Max(100))
+				return r.Value, r.Error
+			},
+			value: 20,
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "Min greater than max is not swapped",
+			query: "x=20",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "x", Min(30), Max(18))
+				return r.Value, r.Error
+			},
+			value: 0,
+			err:   ErrInvalidOption,
+		},
+		{
+			name:  "Integer option for float",
+			query: "t=36.6",
+			parse: func(u *url.URL) (any, error) {
+				r := Float(u, "t", Default(36), Min(35), Max(42))
+				return r.Value, r.Error
+			},
+			value: 36.6,
+		},
+		{
+			name:  "Fractional option for int",
+			query: "x=1",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "x", Default(1.5))
+				return r.Value, r.Error
+			},
+			value: 0,
+			err:   ErrInvalidOption,
+		},
+		{
+			name:  "Float32 window",
+			query: "lat=91",
+			parse: func(u *url.URL) (any, error) {
+				r := Float32(u, "lat", Min(-90), Max(90))
+				return r.Value, r.Error
+			},
+			value: float32(0),
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "String valid value",
+			query: "role=admin",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "role", Default("user"), OneOf("user", "admin"))
+				return r.Value, r.Error
+			},
+			value: "admin",
+		},
+		{
+			name:  "String not allowed",
+			query: "role=root",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "role", Default("user"), OneOf("user", "admin"))
+				return r.Value, r.Error
+			},
+			value: "user",
+			err:   ErrNotAllowed,
+		},
+		{
+			name:  "Min for string",
+			query: "role=root",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "role", Min("a"))
+				return r.Value, r.Error
+			},
+			value: "",
+			err:   ErrInvalidOption,
+		},
+		{
+			name:  "Bool default",
+			query: "active=",
+			parse: func(u *url.URL) (any, error) {
+				r := Bool(u, "active", Default(true))
+				return r.Value, r.Error
+			},
+			value: true,
+		},
+		{
+			name:  "Duration options as strings",
+			query: "window=2h",
+			parse: func(u *url.URL) (any, error) {
+				r := Duration(u, "window", Default("5m"), Min(time.Second),
+					Max("1h"))
+				return r.Value, r.Error
+			},
+			value: 5 * time.Minute,
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "Time with layouts and window",
+			query: "day=31.01.2024",
+			parse: func(u *url.URL) (any, error) {
+				r := Time(u, "day", Layouts("02.01.2006"),
+					Min("01.01.2024"), Max("31.12.2024"))
+				return r.Value, r.Error
+			},
+			value: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "Unix with window",
+			query: "ts=1700000000123",
+			parse: func(u *url.URL) (any, error) {
+				r := Unix(u, "ts", UnixAuto, Min(1700000000000),
+					Max(time.Unix(1800000000, 0)))
+				return r.Value, r.Error
+			},
+			value: time.UnixMilli(1700000000123).UTC(),
+		},
+		{
+			name:  "Unix out of window",
+			query: "ts=1600000000",
+			parse: func(u *url.URL) (any, error) {
+				r := Unix(u, "ts", UnixSeconds, Min(1700000000),
+					Default(time.Unix(1700000000, 0).UTC()))
+				return r.Value, r.Error
+			},
+			value: time.Unix(1700000000, 0).UTC(),
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "Unix slice",
+			query: "ts=1700000000,1700000000",
+			parse: func(u *url.URL) (any, error) {
+				r := UnixSlice(u, "ts", UnixSeconds, Unique())
+				return r.Value, r.Error
+			},
+			value: []time.Time{},
+			err:   ErrNotUnique,
+		},
+		{
+			name:  "Layouts for Unix",
+			query: "ts=1700000000",
+			parse: func(u *url.URL) (any, error) {
+				r := Unix(u, "ts", UnixSeconds, Layouts(time.DateOnly))
+				return r.Value, r.Error
+			},
+			value: time.Time{},
+			err:   ErrInvalidOption,
+		},
+		{
+			name:  "Layouts for int",
+			query: "x=1",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "x", Layouts(time.DateOnly))
+				return r.Value, r.Error
+			},
+			value: 0,
+			err:   ErrInvalidOption,
+		},
+		{
+			name:  "Required present",
+			query: "id=7",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "id", Required())
+				return r.Value, r.Error
+			},
+			value: 7,
+		},
+		{
+			name:  "Required absent",
+			query: "",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "id", Required(), Default(1))
+				return r.Value, r.Error
+			},
+			value: 1,
+			err:   ErrRequired,
+		},
+		{
+			name:  "Required empty",
+			query: "name=",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "name", Required())
+				return r.Value, r.Error
+			},
+			value: "",
+			err:   ErrEmpty,
+		},
+		{
+			name:  "Required slice absent",
+			query: "",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[[]int](u, "ids", Required())
+				return r.Value, r.Error
+			},
+			value: []int{},
+			err:   ErrRequired,
+		},
+		{
+			name:  "Duplicate first by default",
+			query: "limit=10&limit=1000",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "limit", Max(100))
+				return r.Value, r.Error
+			},
+			value: 10,
+		},
+		{
+			name:  "Duplicate last",
+			query: "limit=10&limit=1000",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "limit", Max(100), Duplicates(DuplicateLast))
+				return r.Value, r.Error
+			},
+			value: 0,
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "Duplicate rejected",
+			query: "limit=10&limit=20",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "limit", Default(5), Duplicates(DuplicateReject))
+				return r.Value, r.Error
+			},
+			value: 5,
+			err:   ErrDuplicate,
+		},
+		{
+			name:  "Single value not rejected",
+			query: "limit=10",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "limit", Duplicates(DuplicateReject))
+				return r.Value, r.Error
+			},
+			value: 10,
+		},
+		{
+			name:  "Duplicates of a slice",
+			query: "ids=1&ids=2",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids", Duplicates(DuplicateReject))
+				return r.Value, r.Error
+			},
+			value: []int{1, 2},
+		},
+		{
+			name:  "Slice default",
+			query: "",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids", Default([]int64{1, 2}))
+				return r.Value, r.Error
+			},
+			value: []int{1, 2},
+		},
+		{
+			name:  "Slice elements in range",
+			query: "ids=1,2,3",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids", Min(1), Max(3))
+				return r.Value, r.Error
+			},
+			value: []int{1, 2, 3},
+		},
+		{
+			name:  "Slice element out of range",
+			query: "ids=1,5,3",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids", Min(1), Max(3))
+				return r.Value, r.Error
+			},
+			value: []int{},
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "Slice element not allowed",
+			query: "sort=id&sort=size",
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "sort", OneOf("id", "name"))
+				return r.Value, r.Error
+			},
+			value: []string{},
+			err:   ErrNotAllowed,
+		},
+		{
+			name:  "Slice default is not a slice",
+			query: "",
+			parse: func(u *url.URL) (any, error) {
+				r := BoolSlice(u, "flags", Default(true))
+				return r.Value, r.Error
+			},
+			value: []bool{},
+			err:   ErrInvalidOption,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			value, err := tc.parse(u)
+
+			if !reflect.DeepEqual(value, tc.value) {
+				t.Errorf(".Value: got = %v, want %v", value, tc.value)
+			}
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf(".Error: got = %v, want %v", err, tc.err)
+			}
+		})
+	}
+}
+
+// TestOptionsLegacy tests that the variadic functions are equivalent
+// to the functions with options.
+func TestOptionsLegacy(t *testing.T) {
+	u, _ := url.Parse("http://example.com?age=55&role=root&ids=1,2")
+
+	tests := []struct {
+		name    string
+		legacy  any
+		options any
+	}{
+		{
+			name:   "Int",
+			legacy: ParseInt(u, "age", 30, 18, 40, 50),
+			options: Int(u, "age", Default(30), Min(18), Max(30),
+				OneOf(40, 50)),
+		},
+		{
+			name:    "Float",
+			legacy:  ParseFloat(u, "age", 1.5),
+			options: Float(u, "age", Default(1.5)),
+		},
+		{
+			name:   "String",
+			legacy: ParseString(u, "role", "user", "user", "admin"),
+			options: String(u, "role", Default("user"),
+				OneOf("user", "user", "admin")),
+		},
+		{
+			name:    "Int slice",
+			legacy:  ParseIntSlice(u, "ids", []int{3}),
+			options: IntSlice(u, "ids", Default([]int{3})),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tc.legacy, tc.options) {
+				t.Errorf("got = %+v, want %+v", tc.options, tc.legacy)
+			}
+		})
+	}
+}
+
+// TestOptionsError tests the error messages of the constraints.
+func TestOptionsError(t *testing.T) {
+	u, _ := url.Parse("http://example.com?x=5&ids=1,7")
+
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "Min",
+			err:      Int(u, "x", Min(10)).Error,
+			expected: "value out of range for key x: 5 (min 10)",
+		},
+		{
+			name: "Max and valid values",
+			err:  Int(u, "x", Max(3), OneOf(7, 9)).Error,
+			expected: "value out of range for key x: 5 " +
+				"(max 3 or one of [7 9])",
+		},
+		{
+			name:     "Valid values",
+			err:      Int(u, "x", OneOf(7, 9)).Error,
+			expected: "value not allowed for key x: 5 (one of [7 9])",
+		},
+		{
+			name:     "Slice element",
+			err:      IntSlice(u, "ids", Max(5)).Error,
+			expected: "value out of range for key ids[1]: 7 (max 5)",
+		},
+		{
+			name: "Item count",
+			err:  IntSlice(u, "ids", MinItems(3)).Error,
+			expected: "invalid number of items for key ids: 1,7 " +
+				"(min 3 items)",
+		},
+		{
+			name: "Invalid option",
+			err:  Int(u, "x", Default("five")).Error,
+			expected: "invalid option for key x: Default(five): " +
+				"strconv.ParseInt: parsing \"five\": invalid syntax",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.err == nil || tc.err.Error() != tc.expected {
+				t.Errorf(".Error: got = %v, want %s", tc.err, tc.expected)
+			}
+		})
+	}
+}
+
+// TestOutOfRange tests the out-of-range policies.
+func TestOutOfRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		parse    func(u *url.URL) any
+		expected any
+	}{
+		{
+			name:  "Error",
+			query: "limit=5000",
+			parse: func(u *url.URL) any {
+				return Int(u, "limit", Default(20), Min(1), Max(100))
+			},
+			expected: &Result[int]{
+				Key: "limit", Value: 20, Default: 20, Min: 1, Max: 100,
+				Contains: true, Count: 1,
+				Error: Int(FromValues(url.Values{"limit": {"5000"}}),
+					"limit", Min(1), Max(100)).Error,
+			},
+		},
+		{
+			name:  "Default",
+			query: "limit=5000",
+			parse: func(u *url.URL) any {
+				return Int(u, "limit", Default(20), Min(1), Max(100),
+					OutOfRange(RangeDefault))
+			},
+			expected: &Result[int]{
+				Key: "limit", Value: 20, Default: 20, Min: 1, Max: 100,
+				Contains: true, Count: 1, Adjusted: true,
+			},
+		},
+		{
+			name:  "Clamp to max",
+			query: "limit=5000",
+			parse: func(u *url.URL) any {
+				return Int(u, "limit", Default(20), Min(1), Max(100),
+					OutOfRange(RangeClamp))
+			},
+			expected: &Result[int]{
+				Key: "limit", Value: 100, Default: 20, Min: 1, Max: 100,
+				Contains: true, Count: 1, Adjusted: true,
+			},
+		},
+		{
+			name:  "Clamp to min",
+			query: "zoom=-3.5",
+			parse: func(u *url.URL) any {
+				return Float(u, "zoom", Min(0), OutOfRange(RangeClamp))
+			},
+			expected: &Result[float64]{
+				Key: "zoom", Value: 0, Contains: true, Count: 1,
+				Adjusted: true,
+			},
+		},
+		{
+			name:  "In range is not adjusted",
+			query: "limit=50",
+			parse: func(u *url.URL) any {
+				return Int(u, "limit", Min(1), Max(100),
+					OutOfRange(RangeClamp))
+			},
+			expected: &Result[int]{
+				Key: "limit", Value: 50, Min: 1, Max: 100, Contains: true,
+				Count: 1,
+			},
+		},
+		{
+			name:  "Valid value outside the range",
+			query: "limit=500",
+			parse: func(u *url.URL) any {
+				return Int(u, "limit", Min(1), Max(100), OneOf(500),
+					OutOfRange(RangeClamp))
+			},
+			expected: &Result[int]{
+				Key: "limit", Value: 500, Min: 1, Max: 100,
+				Others: []int{500}, Contains: true, Count: 1,
+			},
+		},
+		{
+			name:  "Clamp slice elements",
+			query: "levels=0,5,30",
+			parse: func(u *url.URL) any {
+				return Parse[[]uint8](u, "levels", Min(1), Max(20),
+					OutOfRange(RangeClamp))
+			},
+			expected: &Result[[]uint8]{
+				Key: "levels", Value: []uint8{1, 5, 20}, Default: []uint8{},
+				Contains: true, Count: 1, Adjusted: true,
+			},
+		},
+		{
+			name:  "Default slice",
+			query: "levels=0,5,30",
+			parse: func(u *url.URL) any {
+				return IntSlice(u, "levels", Default([]int{1}), Min(1),
+					OutOfRange(RangeDefault))
+			},
+			expected: &Result[[]int]{
+				Key: "levels", Value: []int{1}, Default: []int{1},
+				Contains: true, Count: 1, Adjusted: true,
+			},
+		},
+		{
+			name:  "Clamp duration",
+			query: "ttl=48h",
+			parse: func(u *url.URL) any {
+				return Duration(u, "ttl", Max("24h"), OutOfRange(RangeClamp))
+			},
+			expected: &Result[time.Duration]{
+				Key: "ttl", Value: 24 * time.Hour, Max: 24 * time.Hour,
+				Contains: true, Count: 1, Adjusted: true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			if got := tc.parse(u); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("got = %+v, want %+v", got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestConstraints tests the exclusive bounds and the steps.
+func TestConstraints(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		parse      func(u *url.URL) (any, error)
+		value      any
+		err        error
+		constraint string
+	}{
+		{
+			name:  "Exclusive min",
+			query: "price=0",
+			parse: func(u *url.URL) (any, error) {
+				r := Float(u, "price", ExclusiveMin(0))
+				return r.Value, r.Error
+			},
+			value:      0.0,
+			err:        ErrOutOfRange,
+			constraint: "greater than 0",
+		},
+		{
+			name:  "Exclusive min valid",
+			query: "price=0.01",
+			parse: func(u *url.URL) (any, error) {
+				r := Float(u, "price", ExclusiveMin(0))
+				return r.Value, r.Error
+			},
+			value: 0.01,
+		},
+		{
+			name:  "Exclusive max",
+			query: "ratio=1",
+			parse: func(u *url.URL) (any, error) {
+				r := Float(u, "ratio", Min(0), ExclusiveMax(1))
+				return r.Value, r.Error
+			},
+			value:      0.0,
+			err:        ErrOutOfRange,
+			constraint: "range [0, 1)",
+		},
+		{
+			name:  "Exclusive max only",
+			query: "x=10",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "x", ExclusiveMax(10), OneOf(10))
+				return r.Value, r.Error
+			},
+			value: 10,
+		},
+		{
+			name:  "Exclusive range",
+			query: "x=5",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "x", ExclusiveMin(5), ExclusiveMax(9))
+				return r.Value, r.Error
+			},
+			value:      0,
+			err:        ErrOutOfRange,
+			constraint: "range (5, 9)",
+		},
+		{
+			name:  "Min replaces exclusive min",
+			query: "x=5",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "x", ExclusiveMin(5), Min(5))
+				return r.Value, r.Error
+			},
+			value: 5,
+		},
+		{
+			name:  "Multiple of",
+			query: "limit=30",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "limit", Min(10), Max(100), MultipleOf(10))
+				return r.Value, r.Error
+			},
+			value: 30,
+		},
+		{
+			name:  "Not a multiple",
+			query: "limit=35",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "limit", Default(20), MultipleOf(10))
+				return r.Value, r.Error
+			},
+			value:      20,
+			err:        ErrNotMultiple,
+			constraint: "multiple of 10",
+		},
+		{
+			name:  "Out of range before the step",
+			query: "limit=150",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "limit", Max(100), MultipleOf(10))
+				return r.Value, r.Error
+			},
+			value:      0,
+			err:        ErrOutOfRange,
+			constraint: "max 100",
+		},
+		{
+			name:  "Valid value is not a multiple",
+			query: "limit=5",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "limit", Max(100), MultipleOf(10), OneOf(5))
+				return r.Value, r.Error
+			},
+			value: 5,
+		},
+		{
+			name:  "Float multiple",
+			query: "step=0.3",
+			parse: func(u *url.URL) (any, error) {
+				r := Float(u, "step", MultipleOf(0.1))
+				return r.Value, r.Error
+			},
+			value: 0.3,
+		},
+		{
+			name:  "Float32 multiple",
+			query: "step=0.7",
+			parse: func(u *url.URL) (any, error) {
+				r := Float32(u, "step", MultipleOf(0.1))
+				return r.Value, r.Error
+			},
+			value: float32(0.7),
+		},
+		{
+			name:  "Float32 price",
+			query: "p=19.99&p=1234.56&p=99999.99",
+			parse: func(u *url.URL) (any, error) {
+				r := Float32Slice(u, "p", MultipleOf(0.01))
+				return r.Value, r.Error
+			},
+			value: []float32{19.99, 1234.56, 99999.99},
+		},
+		{
+			name:  "Float32 price not a multiple",
+			query: "p=19.995",
+			parse: func(u *url.URL) (any, error) {
+				r := Float32(u, "p", MultipleOf(0.01))
+				return r.Value, r.Error
+			},
+			value:      float32(0),
+			err:        ErrNotMultiple,
+			constraint: "multiple of 0.01",
+		},
+		{
+			name:  "Float large price",
+			query: "p=123456789.99",
+			parse: func(u *url.URL) (any, error) {
+				r := Float(u, "p", MultipleOf(0.01))
+				return r.Value, r.Error
+			},
+			value: 123456789.99,
+		},
+		{
+			name:  "Float large price not a multiple",
+			query: "p=123456789.995",
+			parse: func(u *url.URL) (any, error) {
+				r := Float(u, "p", MultipleOf(0.01))
+				return r.Value, r.Error
+			},
+			value:      0.0,
+			err:        ErrNotMultiple,
+			constraint: "multiple of 0.01",
+		},
+		{
+			name:  "Float not a multiple",
+			query: "step=0.35",
+			parse: func(u *url.URL) (any, error) {
+				r := Float(u, "step", MultipleOf(0.1))
+				return r.Value, r.Error
+			},
+			value:      0.0,
+			err:        ErrNotMultiple,
+			constraint: "multiple of 0.1",
+		},
+		{
+			name:  "Unsigned multiple",
+			query: "n=4294967295",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[uint32](u, "n", MultipleOf(5))
+				return r.Value, r.Error
+			},
+			value: uint32(4294967295),
+		},
+		{
+			name:  "Duration multiple",
+			query: "every=90s",
+			parse: func(u *url.URL) (any, error) {
+				r := Duration(u, "every", MultipleOf("1m"))
+				return r.Value, r.Error
+			},
+			value:      time.Duration(0),
+			err:        ErrNotMultiple,
+			constraint: "multiple of 1m0s",
+		},
+		{
+			name:  "Slice elements",
+			query: "sizes=10,25",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "sizes", ExclusiveMin(0), MultipleOf(5))
+				return r.Value, r.Error
+			},
+			value: []int{10, 25},
+		},
+		{
+			name:  "Slice element not a multiple",
+			query: "sizes=10,24",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "sizes", ExclusiveMin(0), MultipleOf(5))
+				return r.Value, r.Error
+			},
+			value:      []int{},
+			err:        ErrNotMultiple,
+			constraint: "multiple of 5",
+		},
+		{
+			name:  "Zero step",
+			query: "x=1",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "x", MultipleOf(0))
+				return r.Value, r.Error
+			},
+			value: 0,
+			err:   ErrInvalidOption,
+		},
+		{
+			name:  "Step for string",
+			query: "x=1",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "x", MultipleOf(2))
+				return r.Value, r.Error
+			},
+			value: "",
+			err:   ErrInvalidOption,
+		},
+		{
+			name:  "Empty exclusive range",
+			query: "x=1",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "x", Min(1), ExclusiveMax(1))
+				return r.Value, r.Error
+			},
+			value: 0,
+			err:   ErrInvalidOption,
+		},
+		{
+			name:  "Clamp to exclusive bound",
+			query: "x=1",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "x", ExclusiveMin(1), OutOfRange(RangeClamp))
+				return r.Value, r.Error
+			},
+			value: 0,
+			err:   ErrInvalidOption,
+		},
+		{
+			name:  "Clamp with a step",
+			query: "limit=5000",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "limit", Min(1), Max(95), MultipleOf(10),
+					OutOfRange(RangeClamp))
+				return r.Value, r.Error
+			},
+			value: 0,
+			err:   ErrInvalidOption,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			value, err := tc.parse(u)
+
+			if !reflect.DeepEqual(value, tc.value) {
+				t.Errorf(".Value: got = %v, want %v", value, tc.value)
+			}
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf(".Error: got = %v, want %v", err, tc.err)
+			}
+
+			var e *ParamError
+			if errors.As(err, &e) && e.Constraint != tc.constraint {
+				t.Errorf(".Constraint: got = %q, want %q", e.Constraint,
+					tc.constraint)
+			}
+		})
+	}
+}
+
+// TestItems tests the constraints of slices as a whole.
+func TestItems(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		parse      func(u *url.URL) (any, error)
+		value      any
+		err        error
+		index      int
+		constraint string
+	}{
+		{
+			name:  "Valid",
+			query: "ids=1,2,3",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids", MinItems(1), MaxItems(3), Unique(),
+					MaxTotalLength(3))
+				return r.Value, r.Error
+			},
+			value: []int{1, 2, 3},
+		},
+		{
+			name:  "Too few items",
+			query: "ids=1",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids", MinItems(2))
+				return r.Value, r.Error
+			},
+			value:      []int{},
+			err:        ErrItemCount,
+			index:      -1,
+			constraint: "min 2 items",
+		},
+		{
+			name:  "Too many items",
+			query: "ids=1&ids=2&ids=3",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids", Default([]int{1}), MinItems(1),
+					MaxItems(2))
+				return r.Value, r.Error
+			},
+			value:      []int{1},
+			err:        ErrItemCount,
+			index:      -1,
+			constraint: "1-2 items",
+		},
+		{
+			name:  "Too many invalid items",
+			query: "ids=a,b,c",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids", MaxItems(2))
+				return r.Value, r.Error
+			},
+			value:      []int{},
+			err:        ErrItemCount,
+			index:      -1,
+			constraint: "max 2 items",
+		},
+		{
+			name:  "Empty with min items",
+			query: "ids=",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids", MinItems(1))
+				return r.Value, r.Error
+			},
+			value: []int{},
+		},
+		{
+			name:  "Not unique",
+			query: "ids=1,2,02",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids", Unique())
+				return r.Value, r.Error
+			},
+			value:      []int{},
+			err:        ErrNotUnique,
+			index:      2,
+			constraint: "unique items",
+		},
+		{
+			name:  "Unique strings",
+			query: "tags=a,A,b",
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "tags", Unique())
+				return r.Value, r.Error
+			},
+			value: []string{"a", "A", "b"},
+		},
+		{
+			name:  "Unique times",
+			query: "at=2024-01-01T00:00:00Z,2024-01-01T02:00:00%2B02:00",
+			parse: func(u *url.URL) (any, error) {
+				r := TimeSlice(u, "at", Unique())
+				return r.Value, r.Error
+			},
+			value:      []time.Time{},
+			err:        ErrNotUnique,
+			index:      1,
+			constraint: "unique items",
+		},
+		{
+			name:  "Unique after clamping",
+			query: "ids=150,200",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids", Max(100), OutOfRange(RangeClamp),
+					Unique())
+				return r.Value, r.Error
+			},
+			value:      []int{},
+			err:        ErrNotUnique,
+			index:      1,
+			constraint: "unique items",
+		},
+		{
+			name:  "Element out of range",
+			query: "ids=1,2,500",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids", Max(100), MaxItems(5))
+				return r.Value, r.Error
+			},
+			value:      []int{},
+			err:        ErrOutOfRange,
+			index:      2,
+			constraint: "max 100",
+		},
+		{
+			name:  "Too long",
+			query: "tags=ab,cd%C3%A9",
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "tags", MaxTotalLength(4))
+				return r.Value, r.Error
+			},
+			value:      []string{},
+			err:        ErrTooLong,
+			index:      -1,
+			constraint: "max total length 4",
+		},
+		{
+			name:  "Total length in runes",
+			query: "tags=ab,cd%C3%A9",
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "tags", MaxTotalLength(5))
+				return r.Value, r.Error
+			},
+			value: []string{"ab", "cdé"},
+		},
+		{
+			name:  "Generic",
+			query: "ids=1,1",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[[]uint8](u, "ids", Unique())
+				return r.Value, r.Error
+			},
+			value:      []uint8{},
+			err:        ErrNotUnique,
+			index:      1,
+			constraint: "unique items",
+		},
+		{
+			name:  "Scalar",
+			query: "x=1",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "x", MaxItems(1))
+				return r.Value, r.Error
+			},
+			value: 0,
+			err:   ErrInvalidOption,
+		},
+		{
+			name:  "Min items greater than max items",
+			query: "ids=1",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids", MinItems(3), MaxItems(2))
+				return r.Value, r.Error
+			},
+			value: []int{},
+			err:   ErrInvalidOption,
+		},
+		{
+			name:  "Negative max items",
+			query: "ids=1",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids", MaxItems(-1))
+				return r.Value, r.Error
+			},
+			value: []int{},
+			err:   ErrInvalidOption,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			value, err := tc.parse(u)
+
+			if !reflect.DeepEqual(value, tc.value) {
+				t.Errorf(".Value: got = %v, want %v", value, tc.value)
+			}
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf(".Error: got = %v, want %v", err, tc.err)
+			}
+
+			var e *ParamError
+			if errors.As(err, &e) && (e.Index != tc.index ||
+				e.Constraint != tc.constraint) {
+				t.Errorf(".Index, .Constraint: got = %d, %q, want %d, %q",
+					e.Index, e.Constraint, tc.index, tc.constraint)
+			}
+		})
+	}
+}
+
+// TestSplit tests the splitting of slices into elements.
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		parse func(u *url.URL) (any, error)
+		value any
+		err   error
+		index int
+	}{
+		{
+			name:  "Single value",
+			query: "names=a,b",
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "names")
+				return r.Value, r.Error
+			},
+			value: []string{"a", "b"},
+		},
+		{
+			name:  "Repeated strings",
+			query: "names=Doe,%20John&names=Smith",
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "names")
+				return r.Value, r.Error
+			},
+			value: []string{"Doe, John", "Smith"},
+		},
+		{
+			name:  "Mixed numbers",
+			query: "ids=1,2&ids=3",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids")
+				return r.Value, r.Error
+			},
+			value: []int{1, 2, 3},
+		},
+		{
+			name:  "Mixed numbers legacy",
+			query: "ids=1,2&ids=3",
+			parse: func(u *url.URL) (any, error) {
+				r := ParseIntSlice(u, "ids")
+				return r.Value, r.Error
+			},
+			value: []int{1, 2, 3},
+		},
+		{
+			name:  "Mixed booleans generic",
+			query: "flags=on,off&flags=yes",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[[]bool](u, "flags")
+				return r.Value, r.Error
+			},
+			value: []bool{true, false, true},
+		},
+		{
+			name:  "Repeated ISO durations",
+			query: "steps=PT1,5H&steps=1m",
+			parse: func(u *url.URL) (any, error) {
+				r := DurationSlice(u, "steps")
+				return r.Value, r.Error
+			},
+			value: []time.Duration{90 * time.Minute, time.Minute},
+		},
+		{
+			name:  "Split each",
+			query: "names=a,b&names=c",
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "names", Split(SplitEach))
+				return r.Value, r.Error
+			},
+			value: []string{"a", "b", "c"},
+		},
+		{
+			name:  "Split none",
+			query: "names=Doe,%20John",
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "names", Split(SplitNone))
+				return r.Value, r.Error
+			},
+			value: []string{"Doe, John"},
+		},
+		{
+			name:  "Split none numbers",
+			query: "ids=1,2&ids=3",
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids", Split(SplitNone))
+				return r.Value, r.Error
+			},
+			value: []int{},
+			err:   ErrInvalidSyntax,
+			index: 0,
+		},
+		{
+			name:  "Separator",
+			query: "prices=1,5|2,25",
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "prices", Separator("|"))
+				return r.Value, r.Error
+			},
+			value: []string{"1,5", "2,25"},
+		},
+		{
+			name:  "Space separator",
+			query: "ids=1+2%203&ids=4",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[[]uint](u, "ids", Separator(" "))
+				return r.Value, r.Error
+			},
+			value: []uint{1, 2, 3, 4},
+		},
+		{
+			name:  "Multi-character separator",
+			query: "q=a::b::c",
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "q", Separator("::"))
+				return r.Value, r.Error
+			},
+			value: []string{"a", "b", "c"},
+		},
+		{
+			name: "Separator of times",
+			query: "at=Mon,%2002%20Jan%202006%2015:04:05%20UTC%3B" +
+				"Tue,%2003%20Jan%202006%2015:04:05%20UTC",
+			parse: func(u *url.URL) (any, error) {
+				r := TimeSlice(u, "at", Layouts(time.RFC1123),
+					Separator(";"))
+				return len(r.Value), r.Error
+			},
+			value: 2,
+		},
+		{
+			name:  "Quoted",
+			query: `names="Doe,%20John",Smith,"say%20""hi""",a"b,""`,
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "names", Quoted())
+				return r.Value, r.Error
+			},
+			value: []string{"Doe, John", "Smith", `say "hi"`, `a"b`, ""},
+		},
+		{
+			name:  "Quoted with separator",
+			query: `names="a%3Bb"%3Bc&names="d"`,
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "names", Quoted(), Separator(";"),
+					Split(SplitEach))
+				return r.Value, r.Error
+			},
+			value: []string{"a;b", "c", "d"},
+		},
+		{
+			name:  "Quoted numbers",
+			query: `ids="1",2`,
+			parse: func(u *url.URL) (any, error) {
+				r := IntSlice(u, "ids", Quoted())
+				return r.Value, r.Error
+			},
+			value: []int{1, 2},
+		},
+		{
+			name:  "Unterminated quote",
+			query: `names=a,"b,c`,
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "names", Quoted())
+				return r.Value, r.Error
+			},
+			value: []string{},
+			err:   ErrInvalidSyntax,
+			index: 1,
+		},
+		{
+			name:  "Text after quote",
+			query: `names="a"b,c`,
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "names", Quoted())
+				return r.Value, r.Error
+			},
+			value: []string{},
+			err:   ErrInvalidSyntax,
+			index: 0,
+		},
+		{
+			name:  "Empty separator",
+			query: "names=a",
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "names", Separator(""))
+				return r.Value, r.Error
+			},
+			value: []string{},
+			err:   ErrInvalidOption,
+		},
+		{
+			name:  "Quote separator",
+			query: "names=a",
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "names", Separator(`"`), Quoted())
+				return r.Value, r.Error
+			},
+			value: []string{},
+			err:   ErrInvalidOption,
+		},
+		{
+			name:  "Scalar",
+			query: "name=a",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "name", Separator(";"))
+				return r.Value, r.Error
+			},
+			value: "",
+			err:   ErrInvalidOption,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			value, err := tc.parse(u)
+
+			if !reflect.DeepEqual(value, tc.value) {
+				t.Errorf(".Value: got = %v, want %v", value, tc.value)
+			}
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf(".Error: got = %v, want %v", err, tc.err)
+			}
+
+			var e *ParamError
+			if errors.As(err, &e) && e.Index != tc.index {
+				t.Errorf(".Index: got = %d, want %d", e.Index, tc.index)
+			}
+		})
+	}
+}
+
+// TestAliases tests the aliases and case-insensitive matching.
+func TestAliases(t *testing.T) {
+	status := []Option{
+		OneOf("open", "in_progress", "closed"),
+		Aliases(map[string]string{"wip": "in_progress"}),
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		parse func(u *url.URL) (any, error)
+		value any
+		err   error
+	}{
+		{
+			name:  "Alias",
+			query: "status=wip",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "status", status...)
+				return r.Value, r.Error
+			},
+			value: "in_progress",
+		},
+		{
+			name:  "Exact case",
+			query: "status=Open",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "status", status...)
+				return r.Value, r.Error
+			},
+			value: "",
+			err:   ErrNotAllowed,
+		},
+		{
+			name:  "Ignore case",
+			query: "status=Open",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "status", append(status, IgnoreCase())...)
+				return r.Value, r.Error
+			},
+			value: "open",
+		},
+		{
+			name:  "Ignore case alias",
+			query: "status=WIP",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "status", append(status, IgnoreCase())...)
+				return r.Value, r.Error
+			},
+			value: "in_progress",
+		},
+		{
+			name:  "Ignore case not allowed",
+			query: "status=opened",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "status", append(status, IgnoreCase())...)
+				return r.Value, r.Error
+			},
+			value: "",
+			err:   ErrNotAllowed,
+		},
+		{
+			name:  "Slice",
+			query: "status=OPEN,wip,Closed",
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "status",
+					append(status, IgnoreCase())...)
+				return r.Value, r.Error
+			},
+			value: []string{"open", "in_progress", "closed"},
+		},
+		{
+			name:  "Slice not allowed",
+			query: "status=open,closed,bogus",
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "status", status...)
+				return r.Value, r.Error
+			},
+			value: []string{},
+			err:   ErrNotAllowed,
+		},
+		{
+			name:  "Named type",
+			query: "status=CLOSED",
+			parse: func(u *url.URL) (any, error) {
+				type Status string
+				r := Parse[Status](u, "status",
+					append(status, IgnoreCase())...)
+				return string(r.Value), r.Error
+			},
+			value: "closed",
+		},
+		{
+			name:  "Alias of a number",
+			query: "limit=all",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "limit", Min(0), Max(100),
+					Aliases(map[string]string{"all": "0"}))
+				return r.Value, r.Error
+			},
+			value: 0,
+		},
+		{
+			name:  "Alias to an invalid value",
+			query: "status=gone",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "status", append(status,
+					Aliases(map[string]string{"gone": "deleted"}))...)
+				return r.Value, r.Error
+			},
+			value: "",
+			err:   ErrNotAllowed,
+		},
+		{
+			name:  "Invalid alias",
+			query: "limit=all",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "limit", Aliases(map[string]string{"all": "x"}))
+				return r.Value, r.Error
+			},
+			value: 0,
+			err:   ErrInvalidOption,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			value, err := tc.parse(u)
+
+			if !reflect.DeepEqual(value, tc.value) {
+				t.Errorf(".Value: got = %v, want %v", value, tc.value)
+			}
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf(".Error: got = %v, want %v", err, tc.err)
+			}
+		})
+	}
+}
+
+// TestStringConstraints tests the length, pattern, charset, trimming and
+// normalization of values.
+func TestStringConstraints(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		parse      func(u *url.URL) (any, error)
+		value      any
+		err        error
+		index      int
+		constraint string
+	}{
+		{
+			name:  "Valid",
+			query: "user=john_doe",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "user", MinLength(3), MaxLength(20),
+					Pattern(`^[a-z_]+$`),
+					Charset("_", unicode.Letter))
+				return r.Value, r.Error
+			},
+			value: "john_doe",
+		},
+		{
+			name:  "Too short",
+			query: "user=jo",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "user", Default("guest"), MinLength(3),
+					MaxLength(20))
+				return r.Value, r.Error
+			},
+			value:      "guest",
+			err:        ErrTooShort,
+			index:      -1,
+			constraint: "length 3-20",
+		},
+		{
+			name:  "Too long in runes",
+			query: "q=%C3%A9%C3%A9%C3%A9",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "q", MaxLength(2))
+				return r.Value, r.Error
+			},
+			value:      "",
+			err:        ErrTooLong,
+			index:      -1,
+			constraint: "max length 2",
+		},
+		{
+			name:  "Length in runes",
+			query: "q=%C3%A9%C3%A9",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "q", MinLength(2), MaxLength(2))
+				return r.Value, r.Error
+			},
+			value: "éé",
+		},
+		{
+			name:  "Pattern",
+			query: "slug=Hello-World",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "slug", Pattern(`^[a-z0-9]+(-[a-z0-9]+)*$`))
+				return r.Value, r.Error
+			},
+			value:      "",
+			err:        ErrPattern,
+			index:      -1,
+			constraint: "pattern ^[a-z0-9]+(-[a-z0-9]+)*$",
+		},
+		{
+			name:  "Charset",
+			query: "user=jo%24hn",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "user", Charset("-_", unicode.Letter,
+					unicode.Digit))
+				return r.Value, r.Error
+			},
+			value:      "",
+			err:        ErrCharset,
+			index:      -1,
+			constraint: "character '$' not allowed",
+		},
+		{
+			name:  "Trim",
+			query: "q=%20%20go%20lang%20",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "q", Trim(), MaxLength(7))
+				return r.Value, r.Error
+			},
+			value: "go lang",
+		},
+		{
+			name:  "Trim to empty",
+			query: "q=%20%20",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "q", Trim(), Required())
+				return r.Value, r.Error
+			},
+			value: "",
+			err:   ErrEmpty,
+			index: -1,
+		},
+		{
+			name:  "Normalize",
+			query: "q=Caf%C3%A9",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "q", Normalize(strings.ToLower),
+					Pattern("^[a-zé]+$"))
+				return r.Value, r.Error
+			},
+			value: "café",
+		},
+		{
+			name:  "NFC",
+			query: "q=Cafe%CC%81",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "q", NFC(), MaxLength(4))
+				return r.Value, r.Error
+			},
+			value: "Caf\u00e9",
+		},
+		{
+			name:  "Trimmed number",
+			query: "id=%2042",
+			parse: func(u *url.URL) (any, error) {
+				r := Int(u, "id", Trim())
+				return r.Value, r.Error
+			},
+			value: 42,
+		},
+		{
+			name:  "Slice element",
+			query: "tags=go,%20web%20,x",
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "tags", Trim(), MinLength(2))
+				return r.Value, r.Error
+			},
+			value:      []string{},
+			err:        ErrTooShort,
+			index:      2,
+			constraint: "min length 2",
+		},
+		{
+			name:  "Slice trimmed",
+			query: "tags=go,%20web%20&tags=api",
+			parse: func(u *url.URL) (any, error) {
+				r := StringSlice(u, "tags", Trim(), Split(SplitEach),
+					Pattern(`^\w+$`))
+				return r.Value, r.Error
+			},
+			value: []string{"go", "web", "api"},
+		},
+		{
+			name:  "Invalid pattern",
+			query: "q=a",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "q", Pattern("("))
+				return r.Value, r.Error
+			},
+			value: "",
+			err:   ErrInvalidOption,
+		},
+		{
+			name:  "Min length greater than max length",
+			query: "q=a",
+			parse: func(u *url.URL) (any, error) {
+				r := String(u, "q", MinLength(5), MaxLength(2))
+				return r.Value, r.Error
+			},
+			value: "",
+			err:   ErrInvalidOption,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			value, err := tc.parse(u)
+
+			if !reflect.DeepEqual(value, tc.value) {
+				t.Errorf(".Value: got = %v, want %v", value, tc.value)
+			}
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf(".Error: got = %v, want %v", err, tc.err)
+			}
+
+			var e *ParamError
+			if errors.As(err, &e) && (e.Index != tc.index ||
+				e.Constraint != tc.constraint) {
+				t.Errorf(".Index, .Constraint: got = %d, %q, want %d, %q",
+					e.Index, e.Constraint, tc.index, tc.constraint)
+			}
+		})
+	}
+}
@@ -14,19 +14,29 @@ import (
 //
 // This type constraint ensures that the parsing functions can safely operate
 // on the provided type parameters without runtime type errors, leveraging
-// Go's type safety features in a generic programming context.
+// Go's type safety features in a generic programming context. Parse, Get
+// and Pull accept any of these types.
 //
 // Example types supported:
 //
-//   - int, float64, string, bool
-//   - []int, []float64, []string, []bool
+//   - int, float64, float32, string, bool
+//   - int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64
+//   - slices of the types above
 type Value interface {
-	~int | ~float64 | ~string | ~bool |
-		~[]int | ~[]float64 | ~[]string | ~[]bool
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64 | ~string | ~bool |
+		~[]int | ~[]int8 | ~[]int16 | ~[]int32 | ~[]int64 |
+		~[]uint | ~[]uint8 | ~[]uint16 | ~[]uint32 | ~[]uint64 |
+		~[]float32 | ~[]float64 | ~[]string | ~[]bool
 }
 
 // Result is a generic type to hold parsed query parameter values.
-type Result[T Value] struct {
+//
+// The type parameter is usually one of the Value types. It is not
+// restricted to them, so that the generic parsers can return results
+// for slices of any supported element type.
+type Result[T any] struct {
 	Key   string // the query parameter name
 	Value T      // the parsed query parameter value
 
@@ -37,6 +47,8 @@ type Result[T Value] struct {
 
 	Empty    bool  // indicates if the query parameter is empty
 	Contains bool  // indicates if the query parameter is present
+	Count    int   // the number of values of the query parameter
+	Adjusted bool  // indicates if an out-of-range value was adjusted
 	Error    error // the error encountered during parsing
 }
 
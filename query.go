@@ -0,0 +1,171 @@
+package qp
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Source is a source of query parameters: a *url.URL or a *Query.
+//
+// The generic functions of the package accept a Source, so they can be
+// used both with a URL and with a Query that has already been parsed.
+type Source interface {
+	Query() url.Values
+}
+
+// Query holds the query parameters of a URL parsed once.
+//
+// The package-level functions parse the raw query string of the URL on
+// every call. A handler that reads several parameters should create a
+// Query instead and use its methods, which have the same semantics as the
+// package-level functions of the same name without the Parse prefix:
+//
+//	q := qp.New(r.URL)
+//	limit := q.Int("limit", 20, 1, 100)
+//	offset, ok := q.GetInt("offset")
+//	ids := q.PullIntSlice("ids")
+//
+// A Query is safe for concurrent use as long as the underlying values
+// are not modified.
+type Query struct {
+	values     url.Values
+	duplicates DuplicatePolicy
+
+	limits  Limits // the limits of the Query, see WithLimits
+	size    int    // the length of the raw query, 0 - unknown
+	skipped int    // the limit that stopped New from parsing, or 0
+
+	consumed *consumed // the keys read from a strict Query, or nil
+}
+
+// DuplicatePolicy specifies which value of a repeated query parameter is
+// used by the scalar parsers, e.g. for "?limit=10&limit=1000". The slice
+// parsers always use all values.
+//
+// The zero value inherits the policy of the Query, which is
+// DuplicateFirst unless set with WithDuplicates.
+type DuplicatePolicy int
+
+const (
+	// DuplicateFirst uses the first value of a repeated parameter.
+	DuplicateFirst DuplicatePolicy = iota + 1
+
+	// DuplicateLast uses the last value of a repeated parameter.
+	DuplicateLast
+
+	// DuplicateReject rejects a repeated parameter with ErrDuplicate,
+	// which prevents HTTP parameter pollution.
+	DuplicateReject
+)
+
+// New parses the query parameters of the given URL and returns
+// a new Query.
+//
+// If the raw query is longer than the MaxQueryLength of the default
+// limits, it is not parsed and every call reports ErrTooLarge, see
+// SetDefaultLimits.
+func New(u *url.URL) *Query {
+	size := len(u.RawQuery)
+	if n := DefaultLimits().MaxQueryLength; exceeds(size, n) {
+		return &Query{values: url.Values{}, size: size, skipped: n}
+	}
+
+	return &Query{values: u.Query(), size: size}
+}
+
+// FromValues returns a new Query for the already parsed values.
+// The values are used as is, without copying.
+func FromValues(values url.Values) *Query {
+	if values == nil {
+		values = url.Values{}
+	}
+
+	return &Query{values: values}
+}
+
+// WithDuplicates returns a copy of the Query that applies the policy to
+// repeated parameters, unless the Duplicates option of a call overrides
+// it. The copy shares the values with the Query.
+//
+// Example Usage:
+//
+//	q := qp.New(r.URL).WithDuplicates(qp.DuplicateReject)
+//	limit := q.Int("limit", 20, 1, 100) // ?limit=10&limit=1000 fails
+func (q *Query) WithDuplicates(policy DuplicatePolicy) *Query {
+	c := *q
+	c.duplicates = policy
+	return &c
+}
+
+// Values returns the parsed query parameters.
+func (q *Query) Values() url.Values {
+	return q.values
+}
+
+// Query returns the parsed query parameters, like Values.
+// It makes *Query a Source.
+func (q *Query) Query() url.Values {
+	return q.values
+}
+
+// query returns the Query for the source. If the source is a *Query,
+// it is returned as is, without parsing the values again. A *url.URL is
+// parsed with New, so the length of its raw query is checked first.
+func query(src Source) *Query {
+	switch src := src.(type) {
+	case *Query:
+		return src
+	case *url.URL:
+		return New(src)
+	}
+
+	return FromValues(src.Query())
+}
+
+// Contains checks if a specified query parameter is present. It returns
+// true if the parameter is present, regardless of whether it has a value
+// or not.
+func (q *Query) Contains(key string) bool {
+	_, present := q.lookup(key)
+	return present
+}
+
+// Empty checks if a specified query parameter is absent or has an empty
+// value.
+func (q *Query) Empty(key string) bool {
+	data, _ := q.lookup(key)
+	return len(data) == 0 || data[0] == ""
+}
+
+// lookup returns the values of the key and records the key as read,
+// see Strict.
+func (q *Query) lookup(key string) ([]string, bool) {
+	q.consume(key)
+	data, ok := q.values[key]
+	return data, ok
+}
+
+// value returns the value of the scalar query parameter chosen by the
+// policy, or by the policy of the Query if it is zero. For a rejected
+// repeated parameter the first value is returned with the error.
+func (q *Query) value(
+	key string,
+	data []string,
+	policy DuplicatePolicy,
+) (string, error) {
+	if len(data) == 0 {
+		return "", nil
+	} else if policy == 0 {
+		policy = q.duplicates
+	}
+
+	switch {
+	case policy == DuplicateLast:
+		return data[len(data)-1], nil
+	case policy == DuplicateReject && len(data) > 1:
+		return data[0], newParamError(ErrDuplicate, key, data[0],
+			fmt.Sprintf("%d values", len(data)))
+	}
+
+	return data[0], nil
+}
@@ -0,0 +1,148 @@
+package qp
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestQuery tests that the Query methods return the same results
+// as the package-level functions.
+func TestQuery(t *testing.T) {
+	u, _ := url.Parse("http://example.com?age=25&temp=36.6&name=alice" +
+		"&active=yes&ids=1,2&temps=1.5&temps=2.5&names=a,b&flags=on,off" +
+		"&empty=")
+	q := New(u)
+
+	tests := []struct {
+		name     string
+		got      any
+		expected any
+	}{
+		{"Int", q.Int("age", 18, 65), ParseInt(u, "age", 18, 65)},
+		{"Float", q.Float("temp"), ParseFloat(u, "temp")},
+		{"String", q.String("name", "bob"), ParseString(u, "name", "bob")},
+		{"Bool", q.Bool("active"), ParseBool(u, "active")},
+		{"IntSlice", q.IntSlice("ids"), ParseIntSlice(u, "ids")},
+		{"FloatSlice", q.FloatSlice("temps"), ParseFloatSlice(u, "temps")},
+		{"StringSlice", q.StringSlice("names"), ParseStringSlice(u, "names")},
+		{"BoolSlice", q.BoolSlice("flags"), ParseBoolSlice(u, "flags")},
+		{"Absent", q.Int("x", 1), ParseInt(u, "x", 1)},
+		{"Empty", q.String("empty", "y"), ParseString(u, "empty", "y")},
+		{"Invalid", q.Int("name"), ParseInt(u, "name")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tc.got, tc.expected) {
+				t.Errorf("got = %+v, want %+v", tc.got, tc.expected)
+			}
+		})
+	}
+
+	if v, ok := q.GetInt("age"); v != 25 || !ok {
+		t.Errorf("GetInt(): got = %v, %v, want 25, true", v, ok)
+	}
+
+	if v := q.PullBool("active"); v == nil || !*v {
+		t.Errorf("PullBool(): got = %v, want true", v)
+	}
+
+	if v := q.PullStringSlice("x"); v != nil {
+		t.Errorf("PullStringSlice(): got = %v, want nil", v)
+	}
+}
+
+// TestFromValues tests the FromValues function and the utility methods.
+func TestFromValues(t *testing.T) {
+	q := FromValues(url.Values{"foo": {"bar"}, "baz": {""}})
+
+	if !q.Contains("foo") || !q.Contains("baz") || q.Contains("qux") {
+		t.Errorf("Contains(): unexpected result")
+	}
+
+	if q.Empty("foo") || !q.Empty("baz") || !q.Empty("qux") {
+		t.Errorf("Empty(): unexpected result")
+	}
+
+	if got := q.Values().Get("foo"); got != "bar" {
+		t.Errorf("Values(): got = %s, want bar", got)
+	}
+
+	if q := FromValues(nil); q.Contains("foo") || q.Values() == nil {
+		t.Errorf("FromValues(nil): unexpected result")
+	}
+}
+
+// TestFromValuesEmptySlice tests a key that is mapped to no values,
+// which FromValues accepts as is.
+func TestFromValuesEmptySlice(t *testing.T) {
+	values := url.Values{"a": {}}
+	q := FromValues(values)
+
+	tests := []struct {
+		name  string
+		empty bool
+		err   error
+	}{
+		{"Int", q.Int("a", 1).Empty, q.Int("a", 1).Error},
+		{"IntSlice", q.IntSlice("a").Empty, q.IntSlice("a").Error},
+		{"Time", Time(q, "a").Empty, Time(q, "a").Error},
+		{"Parse", Parse[[]string](q, "a").Empty,
+			Parse[[]string](q, "a").Error},
+		{"Unsupported", Parse[struct{}](q, "a").Empty, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if !tc.empty || tc.err != nil {
+				t.Errorf("got = %v, %v, want true, nil", tc.empty, tc.err)
+			}
+		})
+	}
+
+	var dst struct {
+		A []int `qp:"a"`
+		B *int  `qp:"a"`
+	}
+	if err := BindValues(values, &dst); err != nil || len(dst.A) != 0 {
+		t.Errorf("BindValues(): got = %+v, %v", dst, err)
+	}
+}
+
+// TestWithDuplicates tests the duplicate policy of a Query.
+func TestWithDuplicates(t *testing.T) {
+	u, _ := url.Parse("http://example.com?limit=10&limit=1000&page=2")
+	q := New(u)
+
+	if v := q.Int("limit"); v.Value != 10 || v.Count != 2 || v.Error != nil {
+		t.Errorf("Int(): got = %+v, want first value", v)
+	}
+
+	last := q.WithDuplicates(DuplicateLast)
+	if v := last.Int("limit"); v.Value != 1000 || v.Count != 2 {
+		t.Errorf("Int(): got = %+v, want last value", v)
+	}
+
+	reject := q.WithDuplicates(DuplicateReject)
+	if v := reject.Int("limit", 20); v.Value != 20 ||
+		!errors.Is(v.Error, ErrDuplicate) {
+		t.Errorf("Int(): got = %+v, want ErrDuplicate", v)
+	}
+
+	if v, ok := reject.GetInt("page"); v != 2 || !ok {
+		t.Errorf("GetInt(): got = %v, %v, want 2, true", v, ok)
+	}
+
+	// The option of a call overrides the policy of the Query.
+	v := Int(reject, "limit", Duplicates(DuplicateFirst))
+	if v.Value != 10 || v.Error != nil {
+		t.Errorf("Int(): got = %+v, want first value", v)
+	}
+
+	// The original Query is not changed.
+	if v := q.Int("limit"); v.Error != nil {
+		t.Errorf("Int(): got = %v, want nil", v.Error)
+	}
+}
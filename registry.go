@@ -0,0 +1,226 @@
+package qp
+
+import (
+	"reflect"
+	"sync"
+)
+
+// registration holds the parsing functions of a type registered with
+// RegisterParser, or of a built-in type, see parserOf. The functions are
+// created where the type is known, so Parse and Bind can use them with
+// only the reflect.Type at hand.
+type registration struct {
+	parser any // parser[T]
+
+	// scalar parses a T query parameter, the fields of the result hold
+	// values of T.
+	scalar func(q *Query, key string, o options) *Result[any]
+
+	// slice parses a []T query parameter, the Value and Default
+	// fields of the result hold []T.
+	slice func(q *Query, key string, o options) *Result[any]
+
+	// sliceOf is the func(*Query, string, options) *Result[[]T] that
+	// parses a []T query parameter without converting the result.
+	sliceOf any
+
+	// bind and bindSlice bind a T and a []T field.
+	bind      func(*Query, reflect.Value, reflect.StructField, fieldTag) error
+	bindSlice func(*Query, reflect.Value, reflect.StructField, fieldTag) error
+
+	// format formats a value for Encode, nil if the value is formatted
+	// by its kind. It returns false if the value cannot be formatted.
+	format func(reflect.Value) (string, bool)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[reflect.Type]*registration{}
+)
+
+// RegisterParser registers the parse function of the type T, so values
+// of T and []T can be read with Parse, Get, Pull and Bind with the same
+// defaults, valid values and typed errors as the built-in types.
+//
+// The function receives the raw value of the query parameter, or of one
+// slice element. Its error is reported as ErrInvalidSyntax, unless it
+// wraps ErrOutOfRange or ErrNotAllowed. The OneOf option and the oneof
+// tag compare values with reflect.DeepEqual. The Min and Max options are
+// supported if T has a method Compare(T) int, like time.Time.
+//
+// A registered type takes precedence over the parser chosen by its kind,
+// e.g. `type Currency string` can be validated and normalized by its own
+// parser. Registering the same type again replaces the parser. It is
+// typically done in an init function, RegisterParser is safe for
+// concurrent use.
+//
+// Example Usage:
+//
+//	type SKU struct {
+//	    Vendor string
+//	    Code   int
+//	}
+//
+//	func parseSKU(str string) (SKU, error) {
+//	    vendor, code, ok := strings.Cut(str, "-")
+//	    if !ok {
+//	        return SKU{}, errors.New("missing dash")
+//	    }
+//	    n, err := strconv.Atoi(code)
+//	    return SKU{Vendor: vendor, Code: n}, err
+//	}
+//
+//	func init() {
+//	    qp.RegisterParser(parseSKU)
+//	}
+//
+//	// ?sku=ACME-42
+//	sku := qp.Parse[SKU](u, "sku")
+//
+//	// ?skus=ACME-1,ACME-2
+//	skus, ok := qp.Get[[]SKU](u, "skus")
+func RegisterParser[T any](parse func(string) (T, error)) {
+	if parse == nil {
+		panic("nil parse function")
+	}
+
+	p := parser[T]{parse: parse}
+	if _, ok := any(*new(T)).(interface{ Compare(T) int }); ok {
+		p.compare = func(a, b T) int {
+			return any(a).(interface{ Compare(T) int }).Compare(b)
+		}
+	}
+
+	register(p, nil)
+}
+
+// register registers the parser of the type T, see RegisterParser, and
+// the function that formats its values for Encode, if any.
+func register[T any](p parser[T], format func(T) (string, bool)) {
+	r := newRegistration(p)
+	if format != nil {
+		r.format = func(rv reflect.Value) (string, bool) {
+			return format(rv.Interface().(T))
+		}
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[reflect.TypeFor[T]()] = r
+}
+
+// newRegistration returns the parsing functions of the type T with the
+// parser.
+func newRegistration[T any](p parser[T]) *registration {
+	sliceOf := func(q *Query, key string, o options) *Result[[]T] {
+		return slice(q, key, resolve(o, p, true), p)
+	}
+
+	return &registration{
+		parser: p,
+		scalar: func(q *Query, key string, o options) *Result[any] {
+			data := scalar(q, key, resolve(o, p, false), p)
+			result := &Result[any]{
+				Key:      data.Key,
+				Value:    data.Value,
+				Default:  data.Default,
+				Min:      data.Min,
+				Max:      data.Max,
+				Empty:    data.Empty,
+				Contains: data.Contains,
+				Count:    data.Count,
+				Adjusted: data.Adjusted,
+				Error:    data.Error,
+			}
+			for _, v := range data.Others {
+				result.Others = append(result.Others, v)
+			}
+			return result
+		},
+		slice: func(q *Query, key string, o options) *Result[any] {
+			data := sliceOf(q, key, o)
+			return &Result[any]{
+				Key:      data.Key,
+				Value:    data.Value,
+				Default:  data.Default,
+				Empty:    data.Empty,
+				Contains: data.Contains,
+				Count:    data.Count,
+				Adjusted: data.Adjusted,
+				Error:    data.Error,
+			}
+		},
+		sliceOf: sliceOf,
+		bind: func(
+			q *Query,
+			fv reflect.Value,
+			field reflect.StructField,
+			tag fieldTag,
+		) error {
+			return bindScalar(q, fv, field, tag, p)
+		},
+		bindSlice: func(
+			q *Query,
+			fv reflect.Value,
+			field reflect.StructField,
+			tag fieldTag,
+		) error {
+			return bindSliceOf(q, fv, field, tag, p)
+		},
+	}
+}
+
+// registered returns the registration of the type, or nil.
+func registered(typ reflect.Type) *registration {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return registry[typ]
+}
+
+// builtins holds the parsing functions of the built-in kinds, which
+// serve the named types based on them too.
+var builtins = map[reflect.Kind]*registration{
+	reflect.Int:     newRegistration(intParser),
+	reflect.Int8:    newRegistration(int8Parser),
+	reflect.Int16:   newRegistration(int16Parser),
+	reflect.Int32:   newRegistration(int32Parser),
+	reflect.Int64:   newRegistration(int64Parser),
+	reflect.Uint:    newRegistration(uintParser),
+	reflect.Uint8:   newRegistration(uint8Parser),
+	reflect.Uint16:  newRegistration(uint16Parser),
+	reflect.Uint32:  newRegistration(uint32Parser),
+	reflect.Uint64:  newRegistration(uint64Parser),
+	reflect.Float32: newRegistration(float32Parser),
+	reflect.Float64: newRegistration(float64Parser),
+	reflect.String:  newRegistration(stringParser),
+	reflect.Bool:    newRegistration(boolParser),
+}
+
+var (
+	// durationFuncs holds the parsing functions of time.Duration, which
+	// is parsed in the duration format instead of as an integer.
+	durationFuncs = newRegistration(durationParser)
+
+	// timeFuncs holds the parsing functions of time.Time, which is
+	// parsed like ParseTime instead of with UnmarshalText, so date-only
+	// values are valid too.
+	timeFuncs = newRegistration(TimeFormat{}.parser())
+)
+
+// parserOf returns the parsing functions of the type for Parse and Bind:
+// of a registered type, of time.Time, of a type that implements
+// encoding.TextUnmarshaler, of time.Duration or of a built-in kind, in
+// this order. It returns nil if the type is not supported.
+func parserOf(typ reflect.Type) *registration {
+	if r := registered(typ); r != nil {
+		return r
+	} else if typ == timeType {
+		return timeFuncs
+	} else if isText(typ) {
+		return newRegistration(textParser(typ))
+	} else if typ == durationType {
+		return durationFuncs
+	}
+
+	return builtins[typ.Kind()]
+}
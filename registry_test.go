@@ -0,0 +1,228 @@
+package qp
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// testSKU is a struct type with a registered parser.
+type testSKU struct {
+	Vendor string
+	Code   int
+}
+
+// testCurrency is a named string type with a registered parser that
+// takes precedence over the string parser.
+type testCurrency string
+
+// testVersion is a registered type with a Compare method.
+type testVersion struct {
+	Major, Minor int
+}
+
+// Compare compares the versions.
+func (v testVersion) Compare(other testVersion) int {
+	if v.Major != other.Major {
+		return v.Major - other.Major
+	}
+
+	return v.Minor - other.Minor
+}
+
+// String returns the version as "major.minor".
+func (v testVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// testTags is a slice type with a registered parser.
+type testTags []string
+
+// errNoDash is returned by the parser of testSKU.
+var errNoDash = errors.New("missing dash")
+
+func init() {
+	RegisterParser(func(str string) (testSKU, error) {
+		vendor, code, ok := strings.Cut(str, "-")
+		if !ok {
+			return testSKU{}, errNoDash
+		}
+
+		n, err := strconv.Atoi(code)
+		return testSKU{Vendor: vendor, Code: n}, err
+	})
+
+	RegisterParser(func(str string) (testCurrency, error) {
+		if len(str) != 3 {
+			return "", fmt.Errorf("currency %q: %w", str, ErrNotAllowed)
+		}
+
+		return testCurrency(strings.ToUpper(str)), nil
+	})
+
+	RegisterParser(func(str string) (testVersion, error) {
+		var v testVersion
+		_, err := fmt.Sscanf(str, "%d.%d", &v.Major, &v.Minor)
+		return v, err
+	})
+
+	RegisterParser(func(str string) (testTags, error) {
+		return strings.Split(str, "."), nil
+	})
+}
+
+// TestRegisterParser tests Parse with the registered parsers.
+func TestRegisterParser(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		parse func(u *url.URL) (any, error)
+		value any
+		err   error
+	}{
+		{
+			name:  "Struct",
+			query: "sku=ACME-42",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[testSKU](u, "sku")
+				return r.Value, r.Error
+			},
+			value: testSKU{"ACME", 42},
+		},
+		{
+			name:  "Struct invalid",
+			query: "sku=ACME42",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[testSKU](u, "sku", Default("NONE-0"))
+				return r.Value, r.Error
+			},
+			value: testSKU{"NONE", 0},
+			err:   ErrInvalidSyntax,
+		},
+		{
+			name:  "Struct valid values",
+			query: "sku=ACME-2",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[testSKU](u, "sku", OneOf("ACME-1", testSKU{"X", 1}))
+				return r.Value, r.Error
+			},
+			value: testSKU{},
+			err:   ErrNotAllowed,
+		},
+		{
+			name:  "Struct slice",
+			query: "skus=A-1,B-2",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[[]testSKU](u, "skus")
+				return r.Value, r.Error
+			},
+			value: []testSKU{{"A", 1}, {"B", 2}},
+		},
+		{
+			name:  "Struct slice default",
+			query: "",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[[]testSKU](u, "skus", Default([]string{"A-1"}))
+				return r.Value, r.Error
+			},
+			value: []testSKU{{"A", 1}},
+		},
+		{
+			name:  "Min for type without Compare",
+			query: "sku=A-1",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[testSKU](u, "sku", Min("A-0"))
+				return r.Value, r.Error
+			},
+			value: testSKU{},
+			err:   ErrInvalidOption,
+		},
+		{
+			name:  "Named string takes precedence",
+			query: "cur=usd",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[testCurrency](u, "cur")
+				return r.Value, r.Error
+			},
+			value: testCurrency("USD"),
+		},
+		{
+			name:  "Typed error of the parser",
+			query: "cur=dollar",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[testCurrency](u, "cur", Default("EUR"))
+				return r.Value, r.Error
+			},
+			value: testCurrency("EUR"),
+			err:   ErrNotAllowed,
+		},
+		{
+			name:  "Compare method",
+			query: "v=1.9",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[testVersion](u, "v", Min("1.10"), Max("2.0"))
+				return r.Value, r.Error
+			},
+			value: testVersion{},
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "Registered slice type",
+			query: "tags=a.b",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[testTags](u, "tags")
+				return r.Value, r.Error
+			},
+			value: testTags{"a", "b"},
+		},
+		{
+			name:  "Unsupported type",
+			query: "x=1",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[complex128](u, "x")
+				return r.Value, r.Error
+			},
+			value: complex128(0),
+			err:   errors.ErrUnsupported,
+		},
+		{
+			name:  "Unsupported element type",
+			query: "x=1",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[[]complex64](u, "x")
+				return r.Value, r.Error
+			},
+			value: []complex64(nil),
+			err:   errors.ErrUnsupported,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			value, err := tc.parse(u)
+
+			if !reflect.DeepEqual(value, tc.value) {
+				t.Errorf(".Value: got = %#v, want %#v", value, tc.value)
+			}
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf(".Error: got = %v, want %v", err, tc.err)
+			}
+		})
+	}
+}
+
+// TestRegisterParserError tests the error message of a registered type.
+func TestRegisterParserError(t *testing.T) {
+	u, _ := url.Parse("http://example.com?v=3.1")
+	err := Parse[testVersion](u, "v", Max("2.0"), OneOf("3.0")).Error
+	expected := "value out of range for key v: 3.1 (max 2.0 or one of [3.0])"
+	if err == nil || err.Error() != expected {
+		t.Errorf(".Error: got = %v, want %s", err, expected)
+	}
+}
@@ -0,0 +1,124 @@
+package qp
+
+import (
+	"errors"
+	"path"
+	"sort"
+	"sync"
+)
+
+// consumed records the keys read from a strict Query, see Query.Strict.
+// It is shared by the copies of the Query.
+type consumed struct {
+	mu    sync.Mutex
+	keys  map[string]bool
+	allow []string
+}
+
+// Strict returns a copy of the Query that records every key read through
+// it: by Parse, Get, Pull and the other functions that take the Query as
+// the source, by its methods, by Bind and by the Validator. Unknown then
+// reports the keys of the query that were never read, e.g. a typo like
+// "?limt=10" that is otherwise silently ignored.
+//
+// The allow patterns list keys that are never reported, e.g. tracking
+// parameters. They have the syntax of path.Match, so "utm_*" matches
+// "utm_source" and "utm_medium". Strict panics if a pattern is malformed.
+//
+// The copies made with WithDuplicates and WithLimits share the recorded
+// keys. Keys read through Values or Query are not recorded.
+//
+// Example Usage:
+//
+//	q := qp.New(r.URL).Strict("utm_*", "fbclid")
+//	limit := q.Int("limit", 20, 1, 100)
+//	sort := qp.Parse[string](q, "sort")
+//
+//	// ?limt=10&utm_source=mail
+//	if err := q.CheckUnknown(); err != nil {
+//	    // "unknown parameter for key limt: 10"
+//	    http.Error(w, err.Error(), http.StatusBadRequest)
+//	    return
+//	}
+func (q *Query) Strict(allow ...string) *Query {
+	for _, pattern := range allow {
+		if _, err := path.Match(pattern, ""); err != nil {
+			panic("invalid allow pattern " + pattern + ": " + err.Error())
+		}
+	}
+
+	c := *q
+	c.consumed = &consumed{
+		keys:  map[string]bool{},
+		allow: append([]string(nil), allow...),
+	}
+
+	return &c
+}
+
+// Bind parses the query parameters into the struct pointed to by dst,
+// like BindValues. The keys of the fields are recorded by a strict Query.
+//
+// Example Usage:
+//
+//	q := qp.New(r.URL).Strict()
+//	err := errors.Join(q.Bind(&filter), q.CheckUnknown())
+func (q *Query) Bind(dst any) error {
+	return bind(q, dst)
+}
+
+// Unknown returns the sorted keys of the query that were not read from
+// the strict Query and are not allowed, see Strict. It returns nil if
+// the Query is not strict.
+func (q *Query) Unknown() []string {
+	c := q.consumed
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var keys []string
+	for key := range q.values {
+		if !c.keys[key] && !c.allowed(key) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// CheckUnknown returns an error for every unknown key, see Unknown,
+// joined with errors.Join, or nil if there are none. The errors are of
+// type *ParamError and wrap ErrUnknownKey.
+func (q *Query) CheckUnknown() error {
+	var errs []error
+	for _, key := range q.Unknown() {
+		errs = append(errs, newParamError(ErrUnknownKey, key,
+			q.values.Get(key), ""))
+	}
+
+	return errors.Join(errs...)
+}
+
+// consume records the key if the Query is strict.
+func (q *Query) consume(key string) {
+	if c := q.consumed; c != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.keys[key] = true
+	}
+}
+
+// allowed reports whether the key matches one of the allow patterns.
+func (c *consumed) allowed(key string) bool {
+	for _, pattern := range c.allow {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+
+	return false
+}
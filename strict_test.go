@@ -0,0 +1,137 @@
+package qp
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestStrict tests the keys reported by a strict Query.
+func TestStrict(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		allow []string
+		read  func(q *Query)
+		keys  []string
+	}{
+		{
+			name:  "All read",
+			query: "limit=10&sort=name",
+			read: func(q *Query) {
+				q.Int("limit", 20)
+				Parse[string](q, "sort")
+			},
+		},
+		{
+			name:  "Typo",
+			query: "limt=10&sort=name",
+			read: func(q *Query) {
+				q.Int("limit", 20)
+				Get[string](q, "sort")
+			},
+			keys: []string{"limt"},
+		},
+		{
+			name:  "Allowed",
+			query: "utm_source=mail&utm_medium=email&fbclid=x&utm=y",
+			allow: []string{"utm_*", "fbclid"},
+			read:  func(q *Query) {},
+			keys:  []string{"utm"},
+		},
+		{
+			name:  "Slices, enums and checks",
+			query: "ids=1,2&status=open&debug&page=",
+			read: func(q *Query) {
+				Pull[[]int](q, "ids")
+				ParseEnum(q, "status", testStatusNames, testOpen)
+				q.Contains("debug")
+				q.Empty("page")
+			},
+		},
+		{
+			name:  "Copies share the keys",
+			query: "limit=10&page=2&x=1",
+			read: func(q *Query) {
+				q.WithDuplicates(DuplicateLast).Int("limit")
+				q.WithLimits(Limits{MaxKeys: 10}).Int("page")
+			},
+			keys: []string{"x"},
+		},
+		{
+			name:  "Validator",
+			query: "limit=10&offset=0&q=go",
+			read: func(q *Query) {
+				var limit, offset int
+				q.Validate().
+					Int(&limit, "limit").
+					Int(&offset, "offset")
+			},
+			keys: []string{"q"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			q := New(u).Strict(tc.allow...)
+			tc.read(q)
+
+			if keys := q.Unknown(); !reflect.DeepEqual(keys, tc.keys) {
+				t.Errorf("Unknown(): got = %v, want %v", keys, tc.keys)
+			}
+
+			err := q.CheckUnknown()
+			if (err != nil) != (tc.keys != nil) ||
+				err != nil && !errors.Is(err, ErrUnknownKey) {
+				t.Errorf("CheckUnknown(): got = %v, want %v", err,
+					ErrUnknownKey)
+			}
+		})
+	}
+}
+
+// TestStrictBind tests a strict Query with Bind.
+func TestStrictBind(t *testing.T) {
+	var dst struct {
+		Limit  int    `qp:"limit"`
+		Active *bool  `qp:"active"`
+		Skip   string `qp:"-"`
+	}
+
+	u, _ := url.Parse("http://example.com?limit=5&limt=10&Skip=a")
+	q := New(u).Strict()
+	if err := q.Bind(&dst); err != nil || dst.Limit != 5 {
+		t.Fatalf("Bind(): got = %+v, %v", dst, err)
+	}
+
+	expected := "unknown parameter for key Skip: a\n" +
+		"unknown parameter for key limt: 10"
+	err := q.CheckUnknown()
+	if err == nil || err.Error() != expected {
+		t.Errorf("CheckUnknown(): got = %v, want %s", err, expected)
+	}
+
+	var e *ParamError
+	if !errors.As(err, &e) || e.Key != "Skip" || e.Raw != "a" {
+		t.Errorf("CheckUnknown(): got = %+v", e)
+	}
+}
+
+// TestStrictNotStrict tests a Query that is not strict.
+func TestStrictNotStrict(t *testing.T) {
+	u, _ := url.Parse("http://example.com?limt=10")
+	q := New(u)
+	if keys, err := q.Unknown(), q.CheckUnknown(); keys != nil ||
+		err != nil {
+		t.Errorf("Unknown(): got = %v, %v, want nil", keys, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Strict(): expected a panic for a malformed pattern")
+		}
+	}()
+	q.Strict("utm_[")
+}
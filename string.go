@@ -1,12 +1,6 @@
 package qp
 
-import (
-	"fmt"
-	"net/url"
-	"strings"
-
-	"github.com/goloop/g"
-)
+import "net/url"
 
 // ParseString parses a string query parameter from the given URL.
 //
@@ -39,51 +33,7 @@ import (
 //	// Valid values: "guest", "admin", "user"
 //	result := ParseString(u, "name", "guest", "admin", "user")
 func ParseString(u *url.URL, key string, opt ...string) *Result[string] {
-	result := &Result[string]{Key: key, Contains: true}
-	data, ok := u.Query()[key]
-
-	// Available values.
-	if len(opt) == 1 {
-		// Default value.
-		result.Default = opt[0]
-		result.Value = result.Default
-	} else if len(opt) > 1 {
-		result.Default = opt[0]
-		result.Value = result.Default
-
-		// Additional valid values.
-		// Default value is part of the valid values.
-		result.Others = make([]string, 0, len(opt))
-		result.Others = append(result.Others, opt...)
-	}
-
-	// Check if the query parameter is empty or missing.
-	if !ok {
-		// The query parameter is missing.
-		result.Empty = true
-		result.Contains = false
-		return result
-	} else if data[0] == "" {
-		// The query parameter is empty.
-		result.Empty = true
-		result.Contains = true
-		return result
-	}
-
-	// Check if the value is in the list of valid values.
-	value := data[0]
-	if len(opt) < 2 {
-		result.Value = value
-	} else {
-		if result.Others != nil && g.In(value, result.Others...) {
-			result.Value = value
-		} else {
-			msg := "value out of range for key %s: %d"
-			result.Error = fmt.Errorf(msg, key, value)
-		}
-	}
-
-	return result
+	return New(u).String(key, opt...)
 }
 
 // GetString is the function to parse a string query parameter
@@ -182,11 +132,22 @@ func PullString(u *url.URL, key string, opt ...string) *string {
 // (e.g., "?names=alice,bob,charlie") or as multiple values (e.g.,
 // "?names=alice&names=bob&names=charlie").
 //
+// The first optional slice is the default value. The second one, if
+// given, holds the valid values of the elements: if any element is not
+// among them, the error is ErrNotAllowed with the index of the element.
+// Use StringSlice with IgnoreCase or Aliases for more lenient matching.
+//
 // Example Usage:
 //
 //	// Simple call.
 //	result := ParseStringSlice(u, "names")
 //
+//	// Call with default value and valid values.
+//	// Default: [open]
+//	// Valid values: open, closed
+//	result := ParseStringSlice(u, "status", []string{"open"},
+//	    []string{"open", "closed"})
+//
 //	// Handling the result.
 //	if result.Contains && !result.Empty && result.Error == nil {
 //	    fmt.Println("Parsed strings:", result.Value)
@@ -202,42 +163,7 @@ func ParseStringSlice(
 	key string,
 	opt ...[]string,
 ) *Result[[]string] {
-	result := &Result[[]string]{Key: key, Contains: true}
-	data, ok := u.Query()[key]
-
-	// Default value.
-	result.Default = []string{} // not nil
-	result.Value = result.Default
-	if len(opt) > 0 {
-		result.Default = opt[0]
-		result.Value = result.Default
-	}
-
-	// Check if the query parameter is empty or missing.
-	if !ok {
-		result.Empty = true
-		result.Contains = false
-		return result
-	} else if data[0] == "" {
-		result.Empty = true
-		result.Contains = true
-		return result
-	}
-
-	// An array can be specified as a single string "?names=alice,bob,charlie"
-	// or as multiple values "?names=alice&names=bob&names=charlie".
-	if len(data) > 1 {
-		// Multiple values.
-		result.Value = make([]string, 0, len(data))
-		for _, str := range data {
-			result.Value = append(result.Value, str)
-		}
-		return result
-	}
-
-	// Single value.
-	result.Value = strings.Split(data[0], ",")
-	return result
+	return New(u).StringSlice(key, opt...)
 }
 
 // GetStringSlice parses an string slice query parameter from the given URL
@@ -299,3 +225,98 @@ func PullStringSlice(u *url.URL, key string, opt ...[]string) []string {
 
 	return data.Value
 }
+
+// String parses a string query parameter from the given source with
+// the options, see Option. The source is a *url.URL or a *Query.
+//
+// Example Usage:
+//
+//	// Default: user, Valid values: user, admin
+//	result := String(u, "role", Default("user"), OneOf("user", "admin"))
+func String(src Source, key string, opts ...Option) *Result[string] {
+	p := stringParser
+	return scalar(query(src), key, resolve(newOptions(opts), p, false), p)
+}
+
+// StringSlice parses a string slice query parameter from the given source
+// with the options. Default takes a slice, the other options apply to
+// every element.
+//
+// Example Usage:
+//
+//	result := StringSlice(u, "sort", OneOf("id", "name", "date"))
+func StringSlice(src Source, key string, opts ...Option) *Result[[]string] {
+	p := stringParser
+	return slice(query(src), key, resolve(newOptions(opts), p, true), p)
+}
+
+// String parses a string query parameter.
+// See ParseString for details.
+func (q *Query) String(key string, opt ...string) *Result[string] {
+	var s spec[string]
+	if len(opt) > 0 {
+		s.def, s.hasDef = opt[0], true
+	}
+
+	// Default value is part of the valid values.
+	if len(opt) > 1 {
+		s.oneOf = append(make([]string, 0, len(opt)), opt...)
+	}
+
+	return scalar(q, key, s, stringParser)
+}
+
+// GetString returns the string query parameter value and a boolean
+// indicating if it is present and valid. See GetString for details.
+func (q *Query) GetString(key string, opt ...string) (string, bool) {
+	data := q.String(key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullString returns a pointer to the string query parameter value
+// or nil if it is absent. See PullString for details.
+func (q *Query) PullString(key string, opt ...string) *string {
+	data := q.String(key, opt...)
+	if !data.Contains {
+		return nil
+	}
+
+	return &data.Value
+}
+
+// StringSlice parses a string slice query parameter.
+// See ParseStringSlice for details.
+func (q *Query) StringSlice(key string, opt ...[]string) *Result[[]string] {
+	s := legacySliceSpec(opt)
+	if len(opt) > 1 {
+		s.oneOf = opt[1]
+	}
+
+	return slice(q, key, s, stringParser)
+}
+
+// GetStringSlice returns the string slice query parameter value and a boolean
+// indicating if it is present and valid. See GetStringSlice for details.
+func (q *Query) GetStringSlice(key string, opt ...[]string) ([]string, bool) {
+	data := q.StringSlice(key, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullStringSlice returns the string slice query parameter value or nil
+// if it is absent. See PullStringSlice for details.
+func (q *Query) PullStringSlice(key string, opt ...[]string) []string {
+	data := q.StringSlice(key, opt...)
+	if !data.Contains {
+		return nil // not default
+	}
+
+	return data.Value
+}
+
+// stringParser is the parser of string values.
+var stringParser = parser[string]{parse: parseString}
+
+// parseString returns the string as is.
+func parseString(str string) (string, error) {
+	return str, nil
+}
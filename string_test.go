@@ -339,6 +339,30 @@ func TestParseStringSlice(t *testing.T) {
 				Error:    nil,
 			},
 		},
+		{
+			name:  "Valid values",
+			query: "names=alice,bob",
+			opt:   [][]string{{"alice"}, {"alice", "bob"}},
+			expected: &Result[[]string]{
+				Key:      "names",
+				Value:    []string{"alice", "bob"},
+				Empty:    false,
+				Contains: true,
+				Error:    nil,
+			},
+		},
+		{
+			name:  "Not a valid value",
+			query: "names=alice,bogus",
+			opt:   [][]string{{"alice"}, {"alice", "bob"}},
+			expected: &Result[[]string]{
+				Key:      "names",
+				Value:    []string{},
+				Empty:    false,
+				Contains: true,
+				Error:    ErrNotAllowed,
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -0,0 +1,246 @@
+package qp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// tagName is the name of the struct tag used by Bind and Encode.
+const tagName = "qp"
+
+// fieldTag holds the options parsed from a qp struct tag.
+//
+// The tag has the form `qp:"name,default=18,min=18,max=65,oneof=70 99"`.
+// The name may be omitted, in which case the field name is used as the
+// query parameter key. List values (oneof, aliases and slice defaults)
+// are separated by spaces, since the comma separates the tag options.
+type fieldTag struct {
+	name string
+
+	def   string
+	min   string
+	max   string
+	oneOf []string
+
+	hasDef bool
+	hasMin bool
+	hasMax bool
+
+	minExclusive bool
+	maxExclusive bool
+	step         string
+	hasStep      bool
+
+	required    bool
+	omitDefault bool
+
+	minItems  int
+	maxItems  int
+	maxLength int
+	unique    bool
+
+	split  SplitMode
+	sep    string
+	quoted bool
+
+	aliases    map[string]string
+	ignoreCase bool
+
+	minRunes   int
+	maxRunes   int
+	pattern    string
+	hasPattern bool
+	trim       bool
+
+	chars      string
+	tables     []*unicode.RangeTable
+	hasCharset bool
+	normalize  func(string) string
+}
+
+// splitModes are the values of the split tag option.
+var splitModes = map[string]SplitMode{
+	"auto": SplitAuto,
+	"each": SplitEach,
+	"none": SplitNone,
+}
+
+// parseTag parses the qp tag of the struct field. It returns false
+// as the second value if the field must be skipped.
+func parseTag(field reflect.StructField) (fieldTag, bool, error) {
+	tag := fieldTag{name: field.Name}
+	raw, ok := field.Tag.Lookup(tagName)
+	if !ok {
+		return tag, true, nil
+	} else if raw == "-" {
+		return tag, false, nil
+	}
+
+	parts := strings.Split(raw, ",")
+	if parts[0] != "" {
+		tag.name = parts[0]
+	}
+
+	for _, part := range parts[1:] {
+		name, value, _ := strings.Cut(part, "=")
+		switch name {
+		case "default":
+			tag.def, tag.hasDef = value, true
+		case "min":
+			tag.min, tag.hasMin, tag.minExclusive = value, true, false
+		case "max":
+			tag.max, tag.hasMax, tag.maxExclusive = value, true, false
+		case "exclusivemin":
+			tag.min, tag.hasMin, tag.minExclusive = value, true, true
+		case "exclusivemax":
+			tag.max, tag.hasMax, tag.maxExclusive = value, true, true
+		case "multipleof":
+			tag.step, tag.hasStep = value, true
+		case "oneof":
+			tag.oneOf = strings.Fields(value)
+		case "minitems", "maxitems", "maxtotallength", "minlength",
+			"maxlength":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return tag, false, fmt.Errorf(
+					"invalid %s %q in qp tag of field %s", name, value,
+					field.Name)
+			}
+			switch name {
+			case "minitems":
+				tag.minItems = n
+			case "maxitems":
+				tag.maxItems = n
+			case "maxtotallength":
+				tag.maxLength = n
+			case "minlength":
+				tag.minRunes = n
+			default:
+				tag.maxRunes = n
+			}
+		case "pattern":
+			tag.pattern, tag.hasPattern = value, true
+		case "trim":
+			tag.trim = true
+		case "charset":
+			if value == "" {
+				return tag, false, fmt.Errorf(
+					"empty charset in qp tag of field %s", field.Name)
+			}
+			tag.chars, tag.tables = tagCharset(value)
+			tag.hasCharset = true
+		case "nfc":
+			tag.normalize = norm.NFC.String
+		case "unique":
+			tag.unique = true
+		case "split":
+			mode, ok := splitModes[value]
+			if !ok {
+				return tag, false, fmt.Errorf(
+					"invalid split %q in qp tag of field %s", value,
+					field.Name)
+			}
+			tag.split = mode
+		case "separator":
+			if value == "" {
+				return tag, false, fmt.Errorf(
+					"empty separator in qp tag of field %s", field.Name)
+			}
+			tag.sep = value
+		case "quoted":
+			tag.quoted = true
+		case "aliases":
+			tag.aliases = make(map[string]string)
+			for _, pair := range strings.Fields(value) {
+				alias, v, ok := strings.Cut(pair, ":")
+				if !ok {
+					return tag, false, fmt.Errorf(
+						"invalid alias %q in qp tag of field %s", pair,
+						field.Name)
+				}
+				tag.aliases[alias] = v
+			}
+		case "ignorecase":
+			tag.ignoreCase = true
+		case "required":
+			tag.required = true
+		case "omitdefault":
+			tag.omitDefault = true
+		default:
+			return tag, false, fmt.Errorf(
+				"unknown option %q in qp tag of field %s", name, field.Name)
+		}
+	}
+
+	return tag, true, nil
+}
+
+// tagCharset converts the value of the charset tag option, a
+// space-separated list of Unicode categories and scripts, e.g. "L Nd" or
+// "Latin", and of other allowed characters, e.g. "-_".
+func tagCharset(value string) (string, []*unicode.RangeTable) {
+	var (
+		chars  strings.Builder
+		tables []*unicode.RangeTable
+	)
+
+	for _, token := range strings.Fields(value) {
+		if table, ok := unicode.Categories[token]; ok {
+			tables = append(tables, table)
+		} else if table, ok := unicode.Scripts[token]; ok {
+			tables = append(tables, table)
+		} else {
+			chars.WriteString(token)
+		}
+	}
+
+	return chars.String(), tables
+}
+
+// walkStruct calls fn for every exported field of the struct value
+// that is not skipped by its tag. Embedded structs without a tag are
+// walked as if their fields belonged to the outer struct.
+//
+// All fields are visited even if fn fails for some of them. The returned
+// error joins the errors of all fields.
+func walkStruct(
+	rv reflect.Value,
+	fn func(reflect.Value, reflect.StructField, fieldTag) error,
+) error {
+	var errs []error
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+
+		// Embedded structs without explicit tag are flattened.
+		_, tagged := field.Tag.Lookup(tagName)
+		if field.Anonymous && !tagged && field.Type.Kind() == reflect.Struct {
+			if err := walkStruct(rv.Field(i), fn); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		} else if !field.IsExported() {
+			continue
+		}
+
+		tag, ok, err := parseTag(field)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		} else if !ok {
+			continue
+		}
+
+		if err := fn(rv.Field(i), field, tag); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,88 @@
+package qp
+
+import (
+	"encoding"
+	"reflect"
+)
+
+var (
+	// textUnmarshalerType is the type of encoding.TextUnmarshaler.
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+	// textMarshalerType is the type of encoding.TextMarshaler.
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// isText reports whether the values of the type can be parsed with
+// encoding.TextUnmarshaler: either the pointer to the type implements it
+// (e.g. netip.Addr, time.Time) or the type is a pointer that implements
+// it (e.g. *big.Int).
+func isText(typ reflect.Type) bool {
+	return reflect.PointerTo(typ).Implements(textUnmarshalerType) ||
+		typ.Kind() == reflect.Pointer && typ.Implements(textUnmarshalerType)
+}
+
+// textParser returns the parser of a type that implements
+// encoding.TextUnmarshaler, see isText. The parsed values are of the
+// type typ. The values are ordered if the type has a method
+// Compare(T) int.
+//
+// The parser works with the reflect.Type only, so it serves both Parse
+// and Bind for any such type and for slices of it.
+func textParser(typ reflect.Type) parser[any] {
+	p := parser[any]{
+		parse: func(str string) (any, error) {
+			var ptr reflect.Value
+			if typ.Kind() == reflect.Pointer &&
+				typ.Implements(textUnmarshalerType) {
+				ptr = reflect.New(typ.Elem())
+			} else {
+				ptr = reflect.New(typ)
+			}
+
+			u := ptr.Interface().(encoding.TextUnmarshaler)
+			if err := u.UnmarshalText([]byte(str)); err != nil {
+				return nil, err
+			}
+
+			if ptr.Type() == typ {
+				return ptr.Interface(), nil
+			}
+
+			return ptr.Elem().Interface(), nil
+		},
+	}
+
+	m, ok := typ.MethodByName("Compare")
+	if ok && m.Type.NumIn() == 2 && m.Type.In(1) == typ &&
+		m.Type.NumOut() == 1 && m.Type.Out(0).Kind() == reflect.Int {
+		p.compare = func(a, b any) int {
+			out := m.Func.Call([]reflect.Value{
+				reflect.ValueOf(a),
+				reflect.ValueOf(b),
+			})
+			return int(out[0].Int())
+		}
+	}
+
+	return p
+}
+
+// formatText formats the value with encoding.TextMarshaler. It returns
+// false if neither the value nor its address implements it.
+func formatText(rv reflect.Value) (string, bool) {
+	if !rv.Type().Implements(textMarshalerType) {
+		if !rv.CanAddr() ||
+			!reflect.PointerTo(rv.Type()).Implements(textMarshalerType) {
+			return "", false
+		}
+		rv = rv.Addr()
+	}
+
+	text, err := rv.Interface().(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return "", false
+	}
+
+	return string(text), true
+}
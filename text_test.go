@@ -0,0 +1,265 @@
+package qp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testLevel is an enum type that implements encoding.TextUnmarshaler
+// and encoding.TextMarshaler.
+type testLevel int
+
+const (
+	testLevelDebug testLevel = iota
+	testLevelInfo
+	testLevelError
+)
+
+// testLevelNames are the names of the levels.
+var testLevelNames = []string{"debug", "info", "error"}
+
+// UnmarshalText parses the name of the level.
+func (l *testLevel) UnmarshalText(text []byte) error {
+	for i, name := range testLevelNames {
+		if strings.EqualFold(name, string(text)) {
+			*l = testLevel(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown level %q", text)
+}
+
+// MarshalText returns the name of the level.
+func (l testLevel) MarshalText() ([]byte, error) {
+	return []byte(testLevelNames[l]), nil
+}
+
+// TestParseText tests Parse with types that implement
+// encoding.TextUnmarshaler.
+func TestParseText(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		parse func(u *url.URL) (any, error)
+		value any
+		err   error
+	}{
+		{
+			name:  "Address",
+			query: "ip=10.0.0.1",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[netip.Addr](u, "ip")
+				return r.Value, r.Error
+			},
+			value: netip.MustParseAddr("10.0.0.1"),
+		},
+		{
+			name:  "Invalid address with default",
+			query: "ip=10.0.0",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[netip.Addr](u, "ip", Default("127.0.0.1"))
+				return r.Value, r.Error
+			},
+			value: netip.MustParseAddr("127.0.0.1"),
+			err:   ErrInvalidSyntax,
+		},
+		{
+			name:  "Address range by Compare method",
+			query: "ip=10.0.1.0",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[netip.Addr](u, "ip", Min("10.0.0.0"),
+					Max(netip.MustParseAddr("10.0.0.255")))
+				return r.Value, r.Error
+			},
+			value: netip.Addr{},
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "Address slice",
+			query: "ip=10.0.0.1,::1",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[[]netip.Addr](u, "ip")
+				return r.Value, r.Error
+			},
+			value: []netip.Addr{
+				netip.MustParseAddr("10.0.0.1"),
+				netip.MustParseAddr("::1"),
+			},
+		},
+		{
+			name:  "Time",
+			query: "t=2024-01-02T03:04:05Z",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[time.Time](u, "t")
+				return r.Value, r.Error
+			},
+			value: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		{
+			name:  "Date-only time",
+			query: "t=2024-01-02",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[time.Time](u, "t", Min("2020-01-01"))
+				return r.Value, r.Error
+			},
+			value: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "Time before the window",
+			query: "t=2019-12-31",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[time.Time](u, "t", Min("2020-01-01"))
+				return r.Value, r.Error
+			},
+			value: time.Time{},
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "Time slice",
+			query: "t=2024-01-02,2024-01-03T10:00:00Z",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[[]time.Time](u, "t")
+				return r.Value, r.Error
+			},
+			value: []time.Time{
+				time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "Pointer type",
+			query: "n=123456789012345678901234567890",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[*big.Int](u, "n")
+				return r.Value.String(), r.Error
+			},
+			value: "123456789012345678901234567890",
+		},
+		{
+			name:  "Not comparable type with valid values",
+			query: "n=3",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[*big.Int](u, "n", Default(1), OneOf(1, 2))
+				return r.Value.String(), r.Error
+			},
+			value: "1",
+			err:   ErrNotAllowed,
+		},
+		{
+			name:  "Enum",
+			query: "level=Error",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[testLevel](u, "level", Default("info"))
+				return r.Value, r.Error
+			},
+			value: testLevelError,
+		},
+		{
+			name:  "Enum slice default",
+			query: "",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[[]testLevel](u, "level",
+					Default([]string{"debug", "info"}))
+				return r.Value, r.Error
+			},
+			value: []testLevel{testLevelDebug, testLevelInfo},
+		},
+		{
+			name:  "Enum slice invalid element",
+			query: "level=debug&level=trace",
+			parse: func(u *url.URL) (any, error) {
+				r := Parse[[]testLevel](u, "level")
+				return r.Value, r.Error
+			},
+			value: []testLevel{},
+			err:   ErrInvalidSyntax,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			value, err := tc.parse(u)
+
+			if !reflect.DeepEqual(value, tc.value) {
+				t.Errorf(".Value: got = %#v, want %#v", value, tc.value)
+			}
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf(".Error: got = %v, want %v", err, tc.err)
+			}
+		})
+	}
+}
+
+// TestBindText tests binding and encoding of types that implement
+// encoding.TextUnmarshaler.
+func TestBindText(t *testing.T) {
+	type target struct {
+		IP     netip.Addr   `qp:"ip,default=127.0.0.1"`
+		Hosts  []netip.Addr `qp:"hosts"`
+		Level  testLevel    `qp:"level,oneof=info error"`
+		Since  *time.Time   `qp:"since,min=2020-01-01"`
+		Amount *big.Int     `qp:"amount"`
+	}
+
+	values, _ := url.ParseQuery("ip=10.0.0.1&hosts=::1,10.0.0.2" +
+		"&level=error&since=2024-01-01T00:00:00Z&amount=100")
+
+	var dst target
+	if err := BindValues(values, &dst); err != nil {
+		t.Fatalf("BindValues() error: %v", err)
+	}
+
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expected := target{
+		IP: netip.MustParseAddr("10.0.0.1"),
+		Hosts: []netip.Addr{
+			netip.MustParseAddr("::1"),
+			netip.MustParseAddr("10.0.0.2"),
+		},
+		Level:  testLevelError,
+		Since:  &since,
+		Amount: big.NewInt(100),
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("BindValues(): got = %+v, want %+v", dst, expected)
+	}
+
+	encoded, err := Encode(&dst)
+	if err != nil {
+		t.Fatalf("Encode() error: %v", err)
+	} else if !reflect.DeepEqual(encoded, values) {
+		t.Errorf("Encode(): got = %v, want %v", encoded, values)
+	}
+
+	// Invalid values are reported and replaced with the defaults.
+	values, _ = url.ParseQuery("ip=x&level=debug&since=2019-01-01T00:00:00Z")
+	dst = target{}
+	err = BindValues(values, &dst)
+	if !errors.Is(err, ErrInvalidSyntax) || !errors.Is(err, ErrNotAllowed) ||
+		!errors.Is(err, ErrOutOfRange) {
+		t.Errorf("BindValues() error: got = %v", err)
+	}
+
+	if dst.IP != netip.MustParseAddr("127.0.0.1") || dst.Level != 0 ||
+		dst.Since == nil || !dst.Since.IsZero() || dst.Amount != nil {
+		t.Errorf("BindValues(): got = %+v", dst)
+	}
+
+	// A time is parsed like ParseTime, so date-only values are valid.
+	values, _ = url.ParseQuery("since=2024-01-01")
+	dst = target{}
+	if err = BindValues(values, &dst); err != nil || dst.Since == nil ||
+		!dst.Since.Equal(since) {
+		t.Errorf("BindValues(): got = %v, %v", dst.Since, err)
+	}
+}
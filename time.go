@@ -0,0 +1,321 @@
+package qp
+
+import (
+	"cmp"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultTimeLayouts are the layouts used to parse time query parameters
+// when no layouts are given: RFC 3339 (with optional fractional seconds)
+// and date-only.
+var DefaultTimeLayouts = []string{time.RFC3339, time.DateOnly}
+
+// errTimeLayout is returned for a value that matches none of the layouts.
+var errTimeLayout = errors.New("no matching layout")
+
+// TimeFormat describes how to parse a time query parameter.
+//
+// The zero value parses RFC 3339 and date-only values in UTC, without
+// a default value and without a window. The Min and Max fields specify
+// the valid window the way the range of ParseInt does, a zero value
+// leaves the corresponding side of the window open.
+//
+// Example Usage:
+//
+//	kyiv, _ := time.LoadLocation("Europe/Kyiv")
+//	since := qp.TimeFormat{
+//	    Location: kyiv,
+//	    Default:  time.Now().AddDate(0, -1, 0),
+//	    Min:      time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+//	}.Parse(u, "since")
+//
+//	if since.Error != nil {
+//	    http.Error(w, since.Error.Error(), http.StatusBadRequest)
+//	    return
+//	}
+type TimeFormat struct {
+	// Layouts are the accepted layouts in the order they are tried,
+	// DefaultTimeLayouts if empty.
+	Layouts []string
+
+	// Location is the location of values without time zone information,
+	// e.g. date-only values. If nil, UTC is used.
+	Location *time.Location
+
+	// Default is the value returned if the parameter is absent, empty
+	// or invalid.
+	Default time.Time
+
+	// Min and Max are the earliest and the latest valid values,
+	// inclusive. A zero value means that the window is not bounded
+	// on that side.
+	Min time.Time
+	Max time.Time
+}
+
+// ParseTime parses a time query parameter from the given URL.
+//
+// The function accepts a URL, a key, and an optional list of layouts
+// in the format of the time package. The layouts are tried in order,
+// if no layouts are provided, DefaultTimeLayouts are used. Values
+// without time zone information are parsed in UTC.
+//
+// If the query parameter is absent, empty or invalid, the zero time is
+// returned. Use TimeFormat to specify a default value, a valid window or
+// the location of zone-less values.
+//
+// Example Usage:
+//
+//	// Accepts "?since=2024-01-01" and "?since=2024-01-01T10:00:00Z".
+//	result := ParseTime(u, "since")
+//
+//	// Custom layouts.
+//	result := ParseTime(u, "since", time.RFC1123, "02.01.2006")
+func ParseTime(u *url.URL, key string, layouts ...string) *Result[time.Time] {
+	return New(u).Time(key, layouts...)
+}
+
+// GetTime parses a time query parameter and returns the value and
+// a boolean indicating, true - if a value was passed in query params and
+// successfully parsed. See ParseTime for details.
+//
+// Example Usage:
+//
+//	until, ok := GetTime(u, "until")
+func GetTime(u *url.URL, key string, layouts ...string) (time.Time, bool) {
+	return New(u).GetTime(key, layouts...)
+}
+
+// PullTime returns a pointer to the parsed time query parameter value,
+// or nil if the parameter is absent. See ParseTime for details.
+//
+// Example Usage:
+//
+//	since := PullTime(u, "since")
+func PullTime(u *url.URL, key string, layouts ...string) *time.Time {
+	return New(u).PullTime(key, layouts...)
+}
+
+// ParseTimeSlice parses a time slice query parameter from the given URL.
+//
+// The function supports query parameters specified as a single string
+// (e.g., "?days=2024-01-01,2024-01-02") or as multiple values (e.g.,
+// "?days=2024-01-01&days=2024-01-02"). A single value is not split at
+// commas if any of the layouts contains a comma (e.g., time.RFC1123).
+//
+// Example Usage:
+//
+//	result := ParseTimeSlice(u, "days", time.DateOnly)
+func ParseTimeSlice(
+	u *url.URL,
+	key string,
+	layouts ...string,
+) *Result[[]time.Time] {
+	return New(u).TimeSlice(key, layouts...)
+}
+
+// GetTimeSlice parses a time slice query parameter and returns the slice
+// of values and a boolean indicating if the value is valid.
+// See ParseTimeSlice for details.
+//
+// Example Usage:
+//
+//	days, ok := GetTimeSlice(u, "days")
+func GetTimeSlice(
+	u *url.URL,
+	key string,
+	layouts ...string,
+) ([]time.Time, bool) {
+	return New(u).GetTimeSlice(key, layouts...)
+}
+
+// PullTimeSlice parses a time slice query parameter and returns the slice
+// of values, or nil if the parameter is absent.
+// See ParseTimeSlice for details.
+//
+// Example Usage:
+//
+//	days := PullTimeSlice(u, "days")
+func PullTimeSlice(u *url.URL, key string, layouts ...string) []time.Time {
+	return New(u).PullTimeSlice(key, layouts...)
+}
+
+// Time parses a time query parameter from the given source with the
+// options, see Option. The source is a *url.URL or a *Query.
+//
+// The Layouts and Location options have the same meaning as the fields
+// of TimeFormat, Min and Max specify the window. The option values may be
+// times or strings in one of the layouts.
+//
+// Example Usage:
+//
+//	result := Time(u, "since", Layouts(time.DateOnly), Min("2020-01-01"),
+//	    Default(time.Now().AddDate(0, -1, 0)))
+func Time(src Source, key string, opts ...Option) *Result[time.Time] {
+	o := newOptions(opts)
+	p := TimeFormat{Layouts: o.layouts, Location: o.location}.parser()
+	return scalar(query(src), key, resolve(o, p, false), p)
+}
+
+// TimeSlice parses a time slice query parameter from the given source
+// with the options. Default takes a slice, the other options apply to
+// every element.
+//
+// Example Usage:
+//
+//	result := TimeSlice(u, "days", Layouts(time.DateOnly))
+func TimeSlice(src Source, key string, opts ...Option) *Result[[]time.Time] {
+	o := newOptions(opts)
+	f := TimeFormat{Layouts: o.layouts, Location: o.location}
+	s := resolve(o, f.parser(), true)
+	s.noSplit = strings.Contains(strings.Join(f.layouts(), ""),
+		cmp.Or(s.sep, ","))
+	return slice(query(src), key, s, f.parser())
+}
+
+// Time parses a time query parameter.
+// See ParseTime for details.
+func (q *Query) Time(key string, layouts ...string) *Result[time.Time] {
+	return TimeFormat{Layouts: layouts}.Parse(q, key)
+}
+
+// GetTime returns the time query parameter value and a boolean
+// indicating if it is present and valid. See GetTime for details.
+func (q *Query) GetTime(key string, layouts ...string) (time.Time, bool) {
+	return TimeFormat{Layouts: layouts}.Get(q, key)
+}
+
+// PullTime returns a pointer to the time query parameter value
+// or nil if it is absent. See PullTime for details.
+func (q *Query) PullTime(key string, layouts ...string) *time.Time {
+	return TimeFormat{Layouts: layouts}.Pull(q, key)
+}
+
+// TimeSlice parses a time slice query parameter.
+// See ParseTimeSlice for details.
+func (q *Query) TimeSlice(
+	key string,
+	layouts ...string,
+) *Result[[]time.Time] {
+	return TimeFormat{Layouts: layouts}.ParseSlice(q, key)
+}
+
+// GetTimeSlice returns the time slice query parameter value and a boolean
+// indicating if it is present and valid. See GetTimeSlice for details.
+func (q *Query) GetTimeSlice(
+	key string,
+	layouts ...string,
+) ([]time.Time, bool) {
+	return TimeFormat{Layouts: layouts}.GetSlice(q, key)
+}
+
+// PullTimeSlice returns the time slice query parameter value or nil
+// if it is absent. See PullTimeSlice for details.
+func (q *Query) PullTimeSlice(key string, layouts ...string) []time.Time {
+	return TimeFormat{Layouts: layouts}.PullSlice(q, key)
+}
+
+// Parse parses a time query parameter from the given source, a *url.URL
+// or a *Query.
+//
+// If the query parameter is absent, empty or invalid, the default value
+// is returned. A value outside the window is reported as ErrOutOfRange.
+func (f TimeFormat) Parse(src Source, key string) *Result[time.Time] {
+	s := f.spec()
+	s.def, s.hasDef = f.Default, true
+	return scalar(query(src), key, s, f.parser())
+}
+
+// Get parses a time query parameter and returns the value and a boolean
+// indicating if it is present and valid.
+func (f TimeFormat) Get(src Source, key string) (time.Time, bool) {
+	data := f.Parse(src, key)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// Pull returns a pointer to the time query parameter value or nil
+// if it is absent.
+func (f TimeFormat) Pull(src Source, key string) *time.Time {
+	data := f.Parse(src, key)
+	if !data.Contains {
+		return nil
+	}
+
+	return &data.Value
+}
+
+// ParseSlice parses a time slice query parameter from the given source.
+// Every element must match one of the layouts and be inside the window.
+// If the query parameter is absent, empty or invalid, an empty slice
+// is returned.
+func (f TimeFormat) ParseSlice(src Source, key string) *Result[[]time.Time] {
+	return slice(query(src), key, f.spec(), f.parser())
+}
+
+// GetSlice parses a time slice query parameter and returns the slice
+// of values and a boolean indicating if the value is valid.
+func (f TimeFormat) GetSlice(src Source, key string) ([]time.Time, bool) {
+	data := f.ParseSlice(src, key)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullSlice parses a time slice query parameter and returns the slice
+// of values, or nil if the parameter is absent.
+func (f TimeFormat) PullSlice(src Source, key string) []time.Time {
+	data := f.ParseSlice(src, key)
+	if !data.Contains {
+		return nil // not default
+	}
+
+	return data.Value
+}
+
+// spec returns the window of the values. A single value of a slice is
+// not split if any of the layouts contains a comma.
+func (f TimeFormat) spec() spec[time.Time] {
+	return spec[time.Time]{
+		min:     f.Min,
+		max:     f.Max,
+		hasMin:  !f.Min.IsZero(),
+		hasMax:  !f.Max.IsZero(),
+		noSplit: strings.Contains(strings.Join(f.layouts(), ""), ","),
+	}
+}
+
+// parser returns the parser of the values, which tries the layouts
+// in order.
+func (f TimeFormat) parser() parser[time.Time] {
+	loc := f.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	return parser[time.Time]{
+		parse: func(str string) (time.Time, error) {
+			for _, layout := range f.layouts() {
+				value, err := time.ParseInLocation(layout, str, loc)
+				if err == nil {
+					return value, nil
+				}
+			}
+
+			return time.Time{}, errTimeLayout
+		},
+		compare: time.Time.Compare,
+		format: func(t time.Time) string {
+			return t.Format(time.RFC3339Nano)
+		},
+	}
+}
+
+// layouts returns the layouts to try.
+func (f TimeFormat) layouts() []string {
+	if len(f.Layouts) == 0 {
+		return DefaultTimeLayouts
+	}
+
+	return f.Layouts
+}
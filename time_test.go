@@ -0,0 +1,241 @@
+package qp
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestParseTime tests the TimeFormat.Parse method and ParseTime.
+func TestParseTime(t *testing.T) {
+	kyiv := time.FixedZone("EET", 2*60*60)
+	jan1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	jan31 := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		query    string
+		format   TimeFormat
+		expected time.Time
+		empty    bool
+		err      error
+	}{
+		{
+			name:     "Date only",
+			query:    "since=2024-01-01",
+			expected: jan1,
+		},
+		{
+			name:     "RFC 3339",
+			query:    "since=2024-01-31T23:59:59Z",
+			expected: jan31,
+		},
+		{
+			name:     "RFC 3339 with offset and fraction",
+			query:    "since=2024-01-01T02:00:00.5%2B02:00",
+			expected: jan1.Add(500 * time.Millisecond),
+		},
+		{
+			name:     "Location of zone-less value",
+			query:    "since=2024-01-01",
+			format:   TimeFormat{Location: kyiv},
+			expected: time.Date(2024, 1, 1, 0, 0, 0, 0, kyiv),
+		},
+		{
+			name:     "Custom layout",
+			query:    "since=31.01.2024",
+			format:   TimeFormat{Layouts: []string{"02.01.2006"}},
+			expected: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Custom layout rejects default layouts",
+			query:    "since=2024-01-31",
+			format:   TimeFormat{Layouts: []string{"02.01.2006"}},
+			expected: time.Time{},
+			err:      ErrInvalidSyntax,
+		},
+		{
+			name:     "Default value",
+			query:    "",
+			format:   TimeFormat{Default: jan1},
+			expected: jan1,
+			empty:    true,
+		},
+		{
+			name:     "Invalid value",
+			query:    "since=yesterday",
+			format:   TimeFormat{Default: jan1},
+			expected: jan1,
+			err:      ErrInvalidSyntax,
+		},
+		{
+			name:     "Inside window",
+			query:    "since=2024-01-15",
+			format:   TimeFormat{Min: jan1, Max: jan31},
+			expected: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Before window",
+			query:    "since=2023-12-31",
+			format:   TimeFormat{Default: jan1, Min: jan1, Max: jan31},
+			expected: jan1,
+			err:      ErrOutOfRange,
+		},
+		{
+			name:     "After open window",
+			query:    "since=2024-02-01",
+			format:   TimeFormat{Max: jan31},
+			expected: time.Time{},
+			err:      ErrOutOfRange,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			result := tc.format.Parse(u, "since")
+
+			loc := tc.format.Location
+			if !result.Value.Equal(tc.expected) ||
+				(loc != nil && result.Value.Location() != loc) {
+				t.Errorf(".Value: got = %v, want %v",
+					result.Value, tc.expected)
+			}
+
+			if result.Empty != tc.empty {
+				t.Errorf(".Empty: got = %v, want %v", result.Empty, tc.empty)
+			}
+
+			if !errors.Is(result.Error, tc.err) {
+				t.Errorf(".Error: got = %v, want %v", result.Error, tc.err)
+			}
+
+			// Without options, the package functions are the same.
+			if len(tc.format.Layouts) != 0 || loc != nil ||
+				!tc.format.Min.IsZero() || !tc.format.Max.IsZero() {
+				return
+			}
+
+			value, ok := GetTime(u, "since")
+			if ok != (tc.err == nil && !tc.empty) ||
+				(ok && !value.Equal(result.Value)) {
+				t.Errorf("GetTime(): got = %v, %v", value, ok)
+			}
+		})
+	}
+}
+
+// TestTimeError tests the error message of a value outside the window.
+func TestTimeError(t *testing.T) {
+	u, _ := url.Parse("http://example.com?since=2023-12-31")
+	format := TimeFormat{
+		Min: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Max: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	expected := "value out of range for key since: 2023-12-31 " +
+		"(range [2024-01-01T00:00:00Z, 2024-01-31T00:00:00Z])"
+	if err := format.Parse(u, "since").Error; err == nil ||
+		err.Error() != expected {
+		t.Errorf(".Error: got = %v, want %s", err, expected)
+	}
+
+	format.Max = time.Time{}
+	expected = "value out of range for key since: 2023-12-31 " +
+		"(min 2024-01-01T00:00:00Z)"
+	if err := format.Parse(u, "since").Error; err == nil ||
+		err.Error() != expected {
+		t.Errorf(".Error: got = %v, want %s", err, expected)
+	}
+}
+
+// TestPullTime tests the PullTime function.
+func TestPullTime(t *testing.T) {
+	u, _ := url.Parse("http://example.com?since=2024-01-01&until=")
+
+	if value := PullTime(u, "since"); value == nil ||
+		!value.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("PullTime(): got = %v, want 2024-01-01", value)
+	}
+
+	if value := PullTime(u, "until"); value == nil || !value.IsZero() {
+		t.Errorf("PullTime(): got = %v, want zero time", value)
+	}
+
+	if value := PullTime(u, "absent"); value != nil {
+		t.Errorf("PullTime(): got = %v, want nil", *value)
+	}
+}
+
+// TestParseTimeSlice tests the time slice functions.
+func TestParseTimeSlice(t *testing.T) {
+	jan1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	jan2 := jan1.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name     string
+		query    string
+		layouts  []string
+		expected []time.Time
+		index    int
+		err      error
+	}{
+		{
+			name:     "Single value",
+			query:    "days=2024-01-01,2024-01-02",
+			expected: []time.Time{jan1, jan2},
+		},
+		{
+			name:     "Multiple values",
+			query:    "days=2024-01-01&days=2024-01-02T00:00:00Z",
+			expected: []time.Time{jan1, jan2},
+		},
+		{
+			name:     "Layout with comma",
+			query:    "days=Mon,+01+Jan+2024+00:00:00+UTC",
+			layouts:  []string{time.RFC1123},
+			expected: []time.Time{jan1},
+		},
+		{
+			name:     "Invalid element",
+			query:    "days=2024-01-01,tomorrow",
+			expected: []time.Time{},
+			index:    1,
+			err:      ErrInvalidSyntax,
+		},
+		{
+			name:     "Absent",
+			query:    "",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			result := ParseTimeSlice(u, "days", tc.layouts...)
+
+			if !errors.Is(result.Error, tc.err) {
+				t.Fatalf(".Error: got = %v, want %v", result.Error, tc.err)
+			}
+
+			var e *ParamError
+			if errors.As(result.Error, &e) && e.Index != tc.index {
+				t.Errorf(".Index: got = %d, want %d", e.Index, tc.index)
+			}
+
+			value := PullTimeSlice(u, "days", tc.layouts...)
+			if !reflect.DeepEqual(value, tc.expected) {
+				t.Errorf("PullTimeSlice(): got = %v, want %v",
+					value, tc.expected)
+			}
+
+			_, ok := GetTimeSlice(u, "days", tc.layouts...)
+			if ok != (tc.err == nil && tc.expected != nil) {
+				t.Errorf("GetTimeSlice(): got ok = %v", ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,411 @@
+package qp
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// UnixUnit is the unit of a Unix timestamp query parameter.
+type UnixUnit int
+
+const (
+	// UnixAuto detects the unit from the magnitude of the value:
+	// up to 11 digits are seconds, up to 14 digits are milliseconds,
+	// up to 17 digits are microseconds, longer values are nanoseconds.
+	UnixAuto UnixUnit = iota
+
+	// UnixSeconds is the unit of timestamps in seconds.
+	UnixSeconds
+
+	// UnixMillis is the unit of timestamps in milliseconds.
+	UnixMillis
+
+	// UnixMicros is the unit of timestamps in microseconds.
+	UnixMicros
+
+	// UnixNanos is the unit of timestamps in nanoseconds.
+	UnixNanos
+)
+
+// ParseUnix parses a Unix timestamp query parameter from the given URL
+// and returns it as a time in UTC.
+//
+// The unit specifies the precision of the timestamp, UnixAuto detects
+// it from the magnitude of the value, so "?ts=1700000000" and
+// "?ts=1700000000123" are both accepted. The timestamp must be
+// representable in nanoseconds, i.e. between the years 1678 and 2262.
+//
+// The optional times have the same meaning as for ParseInt: the first one
+// is the default value, the first two specify the window (min and max)
+// and any additional times are treated as additional valid values. See
+// Unix for the options.
+//
+// Example Usage:
+//
+//	// Simple call with auto-detection of the unit.
+//	result := ParseUnix(u, "ts", UnixAuto)
+//
+//	// Call with default value and window.
+//	// Default: now
+//	// Window:  the last 24 hours
+//	now := time.Now()
+//	day := now.Add(-24 * time.Hour)
+//	result := ParseUnix(u, "since", UnixSeconds, now, day)
+func ParseUnix(
+	u *url.URL,
+	key string,
+	unit UnixUnit,
+	opt ...time.Time,
+) *Result[time.Time] {
+	return New(u).Unix(key, unit, opt...)
+}
+
+// GetUnix parses a Unix timestamp query parameter and returns the time
+// and a boolean indicating, true - if a value was passed in query params
+// and successfully parsed. See ParseUnix for details.
+//
+// Example Usage:
+//
+//	ts, ok := GetUnix(u, "ts", UnixMillis)
+func GetUnix(
+	u *url.URL,
+	key string,
+	unit UnixUnit,
+	opt ...time.Time,
+) (time.Time, bool) {
+	data := ParseUnix(u, key, unit, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullUnix returns a pointer to the parsed Unix timestamp query parameter
+// value, or nil if the parameter is absent. See ParseUnix for details.
+//
+// Example Usage:
+//
+//	ts := PullUnix(u, "ts", UnixAuto)
+func PullUnix(
+	u *url.URL,
+	key string,
+	unit UnixUnit,
+	opt ...time.Time,
+) *time.Time {
+	data := ParseUnix(u, key, unit, opt...)
+	if !data.Contains {
+		return nil
+	}
+
+	return &data.Value
+}
+
+// ParseUnixSlice parses a Unix timestamp slice query parameter from the
+// given URL.
+//
+// The function supports query parameters specified as a single string
+// (e.g., "?ts=1700000000,1700003600") or as multiple values (e.g.,
+// "?ts=1700000000&ts=1700003600"). With UnixAuto the unit is detected
+// for every element separately.
+//
+// Example Usage:
+//
+//	result := ParseUnixSlice(u, "ts", UnixAuto)
+func ParseUnixSlice(
+	u *url.URL,
+	key string,
+	unit UnixUnit,
+	opt ...[]time.Time,
+) *Result[[]time.Time] {
+	return New(u).UnixSlice(key, unit, opt...)
+}
+
+// GetUnixSlice parses a Unix timestamp slice query parameter and returns
+// the slice of times and a boolean indicating if the value is valid.
+//
+// Example Usage:
+//
+//	ts, ok := GetUnixSlice(u, "ts", UnixSeconds)
+func GetUnixSlice(
+	u *url.URL,
+	key string,
+	unit UnixUnit,
+	opt ...[]time.Time,
+) ([]time.Time, bool) {
+	data := ParseUnixSlice(u, key, unit, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullUnixSlice parses a Unix timestamp slice query parameter and returns
+// the slice of times, or nil if the parameter is absent.
+//
+// Example Usage:
+//
+//	ts := PullUnixSlice(u, "ts", UnixAuto)
+func PullUnixSlice(
+	u *url.URL,
+	key string,
+	unit UnixUnit,
+	opt ...[]time.Time,
+) []time.Time {
+	data := ParseUnixSlice(u, key, unit, opt...)
+	if !data.Contains {
+		return nil // not default
+	}
+
+	return data.Value
+}
+
+// Unix parses a Unix timestamp query parameter from the given source with
+// the options, see Option. The source is a *url.URL or a *Query. The
+// unit has the same meaning as for ParseUnix.
+//
+// Min and Max specify the window. The option values may be times or
+// timestamps in the unit, e.g. Min(1700000000) with UnixSeconds. The
+// Layouts and Location options are not supported.
+//
+// Example Usage:
+//
+//	// Default: now, Window: the last 24 hours
+//	now := time.Now()
+//	result := Unix(u, "since", UnixMillis, Default(now),
+//	    Min(now.Add(-24*time.Hour)), Max(now))
+func Unix(
+	src Source,
+	key string,
+	unit UnixUnit,
+	opts ...Option,
+) *Result[time.Time] {
+	p := unit.parser()
+	return scalar(query(src), key, unixSpec(newOptions(opts), p, false), p)
+}
+
+// UnixSlice parses a Unix timestamp slice query parameter from the given
+// source with the options. Default takes a slice, the other options apply
+// to every element.
+//
+// Example Usage:
+//
+//	result := UnixSlice(u, "ts", UnixAuto, Unique(), MaxItems(10))
+func UnixSlice(
+	src Source,
+	key string,
+	unit UnixUnit,
+	opts ...Option,
+) *Result[[]time.Time] {
+	p := unit.parser()
+	return slice(query(src), key, unixSpec(newOptions(opts), p, true), p)
+}
+
+// Unix parses a Unix timestamp query parameter.
+// See ParseUnix for details.
+func (q *Query) Unix(
+	key string,
+	unit UnixUnit,
+	opt ...time.Time,
+) *Result[time.Time] {
+	// The window is checked by the integer machinery in nanoseconds.
+	nanos := make([]unixNano, 0, len(opt))
+	for _, t := range opt {
+		nanos = append(nanos, unixNano(t.UnixNano()))
+	}
+
+	data := scalar(q, key, legacySpec(nanos), numberParser(unit.parse))
+	result := &Result[time.Time]{
+		Key:      key,
+		Empty:    data.Empty,
+		Contains: data.Contains,
+		Count:    data.Count,
+		Adjusted: data.Adjusted,
+		Error:    data.Error,
+	}
+
+	if len(opt) > 0 {
+		result.Default = opt[0]
+	}
+
+	if len(opt) > 1 {
+		result.Min, result.Max = opt[0], opt[1]
+		if result.Min.After(result.Max) {
+			result.Min, result.Max = result.Max, result.Min
+		}
+		result.Others = append([]time.Time(nil), opt[2:]...)
+	}
+
+	result.Value = result.Default
+	if data.Contains && !data.Empty && data.Error == nil {
+		result.Value = data.Value.Time()
+	}
+
+	return result
+}
+
+// GetUnix returns the Unix timestamp query parameter value and a boolean
+// indicating if it is present and valid. See GetUnix for details.
+func (q *Query) GetUnix(
+	key string,
+	unit UnixUnit,
+	opt ...time.Time,
+) (time.Time, bool) {
+	data := q.Unix(key, unit, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullUnix returns a pointer to the Unix timestamp query parameter value
+// or nil if it is absent. See PullUnix for details.
+func (q *Query) PullUnix(
+	key string,
+	unit UnixUnit,
+	opt ...time.Time,
+) *time.Time {
+	data := q.Unix(key, unit, opt...)
+	if !data.Contains {
+		return nil
+	}
+
+	return &data.Value
+}
+
+// UnixSlice parses a Unix timestamp slice query parameter.
+// See ParseUnixSlice for details.
+func (q *Query) UnixSlice(
+	key string,
+	unit UnixUnit,
+	opt ...[]time.Time,
+) *Result[[]time.Time] {
+	data := slice(q, key, spec[unixNano]{}, numberParser(unit.parse))
+	result := &Result[[]time.Time]{
+		Key:      key,
+		Default:  []time.Time{}, // not nil
+		Empty:    data.Empty,
+		Contains: data.Contains,
+		Count:    data.Count,
+		Adjusted: data.Adjusted,
+		Error:    data.Error,
+	}
+
+	if len(opt) > 0 {
+		result.Default = opt[0]
+	}
+
+	switch {
+	case data.Empty:
+		result.Value = result.Default
+	case data.Error != nil:
+		result.Value = []time.Time{} // not nil
+	default:
+		result.Value = make([]time.Time, 0, len(data.Value))
+		for _, n := range data.Value {
+			result.Value = append(result.Value, n.Time())
+		}
+	}
+
+	return result
+}
+
+// GetUnixSlice returns the Unix timestamp slice query parameter value and
+// a boolean indicating if it is present and valid.
+// See GetUnixSlice for details.
+func (q *Query) GetUnixSlice(
+	key string,
+	unit UnixUnit,
+	opt ...[]time.Time,
+) ([]time.Time, bool) {
+	data := q.UnixSlice(key, unit, opt...)
+	return data.Value, data.Contains && !data.Empty && data.Error == nil
+}
+
+// PullUnixSlice returns the Unix timestamp slice query parameter value or
+// nil if it is absent. See PullUnixSlice for details.
+func (q *Query) PullUnixSlice(
+	key string,
+	unit UnixUnit,
+	opt ...[]time.Time,
+) []time.Time {
+	data := q.UnixSlice(key, unit, opt...)
+	if !data.Contains {
+		return nil // not default
+	}
+
+	return data.Value
+}
+
+// unixNano is a Unix time in nanoseconds. It formats itself as a time,
+// so the window in the error messages is readable.
+type unixNano int64
+
+// Time returns the time in UTC.
+func (n unixNano) Time() time.Time {
+	return time.Unix(0, int64(n)).UTC()
+}
+
+// String returns the time in the RFC 3339 format.
+func (n unixNano) String() string {
+	return n.Time().Format(time.RFC3339Nano)
+}
+
+// parser returns the parser of timestamps in the unit as times in UTC,
+// for Unix and UnixSlice.
+func (unit UnixUnit) parser() parser[time.Time] {
+	return parser[time.Time]{
+		parse: func(str string) (time.Time, error) {
+			n, err := unit.parse(str)
+			return n.Time(), err
+		},
+		compare: time.Time.Compare,
+		format: func(t time.Time) string {
+			return t.Format(time.RFC3339Nano)
+		},
+		plain: true,
+	}
+}
+
+// unixSpec resolves the options of a Unix timestamp, which has no
+// layouts.
+func unixSpec(o options, p parser[time.Time], slice bool) spec[time.Time] {
+	s := resolve(o, p, slice)
+	if o.layouts != nil || o.location != nil {
+		s.err = errors.Join(s.err, errors.New("Layouts and Location are "+
+			"not supported for Unix timestamps"))
+	}
+
+	return s
+}
+
+// parse parses a string as a timestamp in the unit and converts it
+// to nanoseconds.
+func (unit UnixUnit) parse(str string) (unixNano, error) {
+	value, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if unit == UnixAuto {
+		switch {
+		case value > -1e11 && value < 1e11:
+			unit = UnixSeconds
+		case value > -1e14 && value < 1e14:
+			unit = UnixMillis
+		case value > -1e17 && value < 1e17:
+			unit = UnixMicros
+		default:
+			unit = UnixNanos
+		}
+	}
+
+	scale := int64(1)
+	switch unit {
+	case UnixSeconds:
+		scale = int64(time.Second)
+	case UnixMillis:
+		scale = int64(time.Millisecond)
+	case UnixMicros:
+		scale = int64(time.Microsecond)
+	}
+
+	if value > math.MaxInt64/scale || value < math.MinInt64/scale {
+		return 0, strconv.ErrRange
+	}
+
+	return unixNano(value * scale), nil
+}
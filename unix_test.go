@@ -0,0 +1,200 @@
+package qp
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestParseUnix tests the ParseUnix function.
+func TestParseUnix(t *testing.T) {
+	ts := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC) // 1700000000
+	day := ts.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		query    string
+		unit     UnixUnit
+		opt      []time.Time
+		expected time.Time
+		err      error
+	}{
+		{
+			name:     "Seconds",
+			query:    "ts=1700000000",
+			unit:     UnixSeconds,
+			expected: ts,
+		},
+		{
+			name:     "Milliseconds",
+			query:    "ts=1700000000123",
+			unit:     UnixMillis,
+			expected: ts.Add(123 * time.Millisecond),
+		},
+		{
+			name:     "Microseconds",
+			query:    "ts=1700000000123456",
+			unit:     UnixMicros,
+			expected: ts.Add(123456 * time.Microsecond),
+		},
+		{
+			name:     "Nanoseconds",
+			query:    "ts=1700000000123456789",
+			unit:     UnixNanos,
+			expected: ts.Add(123456789),
+		},
+		{
+			name:     "Auto seconds",
+			query:    "ts=1700000000",
+			expected: ts,
+		},
+		{
+			name:     "Auto milliseconds",
+			query:    "ts=1700000000123",
+			expected: ts.Add(123 * time.Millisecond),
+		},
+		{
+			name:     "Auto microseconds",
+			query:    "ts=1700000000123456",
+			expected: ts.Add(123456 * time.Microsecond),
+		},
+		{
+			name:     "Auto nanoseconds",
+			query:    "ts=1700000000123456789",
+			expected: ts.Add(123456789),
+		},
+		{
+			name:     "Auto negative seconds",
+			query:    "ts=-86400",
+			expected: time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Explicit unit is not detected",
+			query:    "ts=1700000000",
+			unit:     UnixMillis,
+			expected: time.Date(1970, 1, 20, 16, 13, 20, 0, time.UTC),
+		},
+		{
+			name:     "Overflow",
+			query:    "ts=99999999999999999",
+			unit:     UnixSeconds,
+			opt:      []time.Time{ts},
+			expected: ts,
+			err:      ErrOutOfRange,
+		},
+		{
+			name:     "Invalid",
+			query:    "ts=yesterday",
+			opt:      []time.Time{ts},
+			expected: ts,
+			err:      ErrInvalidSyntax,
+		},
+		{
+			name:     "Default value",
+			query:    "",
+			opt:      []time.Time{ts},
+			expected: ts,
+		},
+		{
+			name:     "No default value",
+			query:    "ts=",
+			expected: time.Time{},
+		},
+		{
+			name:     "Inside window",
+			query:    "ts=1699990000",
+			opt:      []time.Time{ts, day},
+			expected: time.Unix(1699990000, 0).UTC(),
+		},
+		{
+			name:     "Outside window",
+			query:    "ts=1600000000",
+			opt:      []time.Time{ts, day},
+			expected: ts,
+			err:      ErrOutOfRange,
+		},
+		{
+			name:     "Additional value",
+			query:    "ts=0",
+			opt:      []time.Time{ts, day, time.Unix(0, 0)},
+			expected: time.Unix(0, 0).UTC(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse("http://example.com?" + tc.query)
+			result := ParseUnix(u, "ts", tc.unit, tc.opt...)
+
+			if !result.Value.Equal(tc.expected) {
+				t.Errorf(".Value: got = %v, want %v",
+					result.Value, tc.expected)
+			}
+
+			if !errors.Is(result.Error, tc.err) {
+				t.Errorf(".Error: got = %v, want %v", result.Error, tc.err)
+			}
+
+			value, ok := GetUnix(u, "ts", tc.unit, tc.opt...)
+			if !value.Equal(tc.expected) || ok != (tc.err == nil &&
+				!result.Empty) {
+				t.Errorf("GetUnix(): got = %v, %v", value, ok)
+			}
+		})
+	}
+}
+
+// TestUnixError tests the error message of a timestamp out of window.
+func TestUnixError(t *testing.T) {
+	u, _ := url.Parse("http://example.com?ts=1600000000")
+	ts := time.Unix(1700000000, 0)
+
+	result := ParseUnix(u, "ts", UnixAuto, ts, ts.Add(-time.Hour))
+	if !result.Min.Equal(ts.Add(-time.Hour)) || !result.Max.Equal(ts) {
+		t.Errorf(".Min, .Max: got = %v, %v", result.Min, result.Max)
+	}
+
+	expected := "value out of range for key ts: 1600000000 " +
+		"(range [2023-11-14T21:13:20Z, 2023-11-14T22:13:20Z])"
+	if result.Error == nil || result.Error.Error() != expected {
+		t.Errorf(".Error: got = %v, want %s", result.Error, expected)
+	}
+}
+
+// TestParseUnixSlice tests the Unix timestamp slice functions.
+func TestParseUnixSlice(t *testing.T) {
+	u, _ := url.Parse("http://example.com?ts=1700000000,1700000000123" +
+		"&bad=1&bad=x&empty=")
+	ts := time.Unix(1700000000, 0).UTC()
+
+	expected := []time.Time{ts, ts.Add(123 * time.Millisecond)}
+	if value, ok := GetUnixSlice(u, "ts", UnixAuto); !ok ||
+		!reflect.DeepEqual(value, expected) {
+		t.Errorf("GetUnixSlice(): got = %v, %v, want %v, true",
+			value, ok, expected)
+	}
+
+	var e *ParamError
+	result := ParseUnixSlice(u, "bad", UnixSeconds)
+	if !errors.As(result.Error, &e) || e.Index != 1 ||
+		!reflect.DeepEqual(result.Value, []time.Time{}) {
+		t.Errorf("ParseUnixSlice(): got = %v, %v, want error at index 1",
+			result.Value, result.Error)
+	}
+
+	def := []time.Time{ts}
+	value := PullUnixSlice(u, "empty", UnixAuto, def)
+	if !reflect.DeepEqual(value, def) {
+		t.Errorf("PullUnixSlice(): got = %v, want %v", value, def)
+	}
+
+	if value := PullUnixSlice(u, "absent", UnixAuto); value != nil {
+		t.Errorf("PullUnixSlice(): got = %v, want nil", value)
+	}
+
+	if value := PullUnix(u, "absent", UnixAuto); value != nil {
+		t.Errorf("PullUnix(): got = %v, want nil", value)
+	}
+}
@@ -0,0 +1,176 @@
+package qp
+
+import (
+	"errors"
+	"net/url"
+)
+
+// Validator reads several query parameters into variables and collects
+// the errors of all of them, so that a handler can report every invalid
+// parameter at once instead of failing on the first one.
+//
+// Each method parses a parameter like the Query method of the same name,
+// stores the value (the parsed one or the default) into the variable and
+// returns the Validator, so calls can be chained:
+//
+//	var (
+//	    limit, offset int
+//	    sort          string
+//	)
+//
+//	err := qp.Validate(r.URL).
+//	    Int(&limit, "limit", 20, 1, 100).
+//	    Int(&offset, "offset", 0, 0, 10000).
+//	    String(&sort, "sort", "id", "id", "name").
+//	    Err()
+type Validator struct {
+	query *Query
+	errs  []error
+}
+
+// Validate returns a new Validator for the query parameters
+// of the given URL.
+func Validate(u *url.URL) *Validator {
+	return New(u).Validate()
+}
+
+// Validate returns a new Validator for the query parameters.
+func (q *Query) Validate() *Validator {
+	return &Validator{query: q}
+}
+
+// Err returns the errors of all invalid parameters joined with
+// errors.Join, or nil if all parameters are valid.
+func (v *Validator) Err() error {
+	return errors.Join(v.errs...)
+}
+
+// Errors returns the errors of all invalid parameters in the order
+// the parameters were read.
+func (v *Validator) Errors() []error {
+	return append([]error(nil), v.errs...)
+}
+
+// add records the error of a parameter, if any.
+func (v *Validator) add(err error) {
+	if err != nil {
+		v.errs = append(v.errs, err)
+	}
+}
+
+// Int reads an integer query parameter into dst, see ParseInt.
+func (v *Validator) Int(dst *int, key string, opt ...int) *Validator {
+	result := v.query.Int(key, opt...)
+	*dst = result.Value
+	v.add(result.Error)
+	return v
+}
+
+// Float reads a float query parameter into dst, see ParseFloat.
+func (v *Validator) Float(
+	dst *float64,
+	key string,
+	opt ...float64,
+) *Validator {
+	result := v.query.Float(key, opt...)
+	*dst = result.Value
+	v.add(result.Error)
+	return v
+}
+
+// Float32 reads a float32 query parameter into dst, see ParseFloat32.
+func (v *Validator) Float32(
+	dst *float32,
+	key string,
+	opt ...float32,
+) *Validator {
+	result := v.query.Float32(key, opt...)
+	*dst = result.Value
+	v.add(result.Error)
+	return v
+}
+
+// String reads a string query parameter into dst, see ParseString.
+func (v *Validator) String(
+	dst *string,
+	key string,
+	opt ...string,
+) *Validator {
+	result := v.query.String(key, opt...)
+	*dst = result.Value
+	v.add(result.Error)
+	return v
+}
+
+// Bool reads a boolean query parameter into dst, see ParseBool.
+func (v *Validator) Bool(dst *bool, key string, opt ...bool) *Validator {
+	result := v.query.Bool(key, opt...)
+	*dst = result.Value
+	v.add(result.Error)
+	return v
+}
+
+// IntSlice reads an integer slice query parameter into dst,
+// see ParseIntSlice.
+func (v *Validator) IntSlice(
+	dst *[]int,
+	key string,
+	opt ...[]int,
+) *Validator {
+	result := v.query.IntSlice(key, opt...)
+	*dst = result.Value
+	v.add(result.Error)
+	return v
+}
+
+// FloatSlice reads a float slice query parameter into dst,
+// see ParseFloatSlice.
+func (v *Validator) FloatSlice(
+	dst *[]float64,
+	key string,
+	opt ...[]float64,
+) *Validator {
+	result := v.query.FloatSlice(key, opt...)
+	*dst = result.Value
+	v.add(result.Error)
+	return v
+}
+
+// Float32Slice reads a float32 slice query parameter into dst,
+// see ParseFloat32Slice.
+func (v *Validator) Float32Slice(
+	dst *[]float32,
+	key string,
+	opt ...[]float32,
+) *Validator {
+	result := v.query.Float32Slice(key, opt...)
+	*dst = result.Value
+	v.add(result.Error)
+	return v
+}
+
+// StringSlice reads a string slice query parameter into dst,
+// see ParseStringSlice.
+func (v *Validator) StringSlice(
+	dst *[]string,
+	key string,
+	opt ...[]string,
+) *Validator {
+	result := v.query.StringSlice(key, opt...)
+	*dst = result.Value
+	v.add(result.Error)
+	return v
+}
+
+// BoolSlice reads a boolean slice query parameter into dst,
+// see ParseBoolSlice.
+func (v *Validator) BoolSlice(
+	dst *[]bool,
+	key string,
+	opt ...[]bool,
+) *Validator {
+	result := v.query.BoolSlice(key, opt...)
+	*dst = result.Value
+	v.add(result.Error)
+	return v
+}
@@ -0,0 +1,73 @@
+package qp
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestValidate tests the Validator methods.
+func TestValidate(t *testing.T) {
+	u, _ := url.Parse("http://example.com?limit=500&offset=x&sort=name" +
+		"&active=maybe&temp=36.6&ids=1,y&temps=1.5&names=a,b&flags=on")
+
+	var (
+		limit, offset int
+		temp          float64
+		sort          string
+		active        bool
+		ids           []int
+		temps         []float64
+		names         []string
+		flags         []bool
+	)
+
+	v := Validate(u).
+		Int(&limit, "limit", 20, 1, 100).
+		Int(&offset, "offset").
+		Float(&temp, "temp").
+		String(&sort, "sort", "id", "id", "name").
+		Bool(&active, "active", true).
+		IntSlice(&ids, "ids", []int{1}).
+		FloatSlice(&temps, "temps").
+		StringSlice(&names, "names").
+		BoolSlice(&flags, "flags")
+
+	if limit != 20 || offset != 0 || temp != 36.6 || sort != "name" ||
+		!active {
+		t.Errorf("unexpected values: %v %v %v %v %v",
+			limit, offset, temp, sort, active)
+	}
+
+	if !reflect.DeepEqual(ids, []int{}) ||
+		!reflect.DeepEqual(temps, []float64{1.5}) ||
+		!reflect.DeepEqual(names, []string{"a", "b"}) ||
+		!reflect.DeepEqual(flags, []bool{true}) {
+		t.Errorf("unexpected slices: %v %v %v %v", ids, temps, names, flags)
+	}
+
+	errs := v.Errors()
+	keys := make([]string, 0, len(errs))
+	for _, err := range errs {
+		var e *ParamError
+		if !errors.As(err, &e) {
+			t.Fatalf("errors.As(): got = %T, want *ParamError", err)
+		}
+		keys = append(keys, e.Key)
+	}
+
+	expected := []string{"limit", "offset", "active", "ids"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Errors(): got keys = %v, want %v", keys, expected)
+	}
+
+	err := v.Err()
+	if !errors.Is(err, ErrOutOfRange) || !errors.Is(err, ErrInvalidSyntax) {
+		t.Errorf("Err(): got = %v, want joined errors", err)
+	}
+
+	if err := New(u).Validate().Int(&limit, "limit").Err(); err != nil {
+		t.Errorf("Err(): got = %v, want nil", err)
+	}
+}
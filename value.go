@@ -0,0 +1,785 @@
+package qp
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// numeric is a constraint that permits any integer or floating-point type.
+type numeric interface {
+	Integer | Floating
+}
+
+// parser describes how to parse, compare and format the values of
+// a query parameter of type T.
+type parser[T any] struct {
+	parse    func(string) (T, error)  // converts a raw value
+	compare  func(a, b T) int         // orders values, nil if unordered
+	format   func(T) string           // formats values in errors, or nil
+	multiple func(value, step T) bool // checks steps, nil if unsupported
+
+	// plain is true if the values cannot contain a separator, so every
+	// value of a slice is split, see SplitAuto.
+	plain bool
+
+	// fold matches a raw value ignoring case for IgnoreCase, e.g. the
+	// names of an enum, or nil.
+	fold func(string) (T, bool)
+}
+
+// numberParser returns the parser of a numeric type with the given
+// parse function.
+func numberParser[T numeric](parse func(string) (T, error)) parser[T] {
+	p := parser[T]{parse: parse, compare: cmp.Compare[T], plain: true}
+	p.multiple = func(value, step T) bool {
+		return value/step*step == value
+	}
+
+	// The floats are compared with a tolerance of their precision,
+	// so 0.3 is a multiple of 0.1.
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Float32:
+		p.multiple = floatMultiple[T](0x1p-23)
+	case reflect.Float64:
+		p.multiple = floatMultiple[T](0x1p-52)
+	}
+
+	return p
+}
+
+// floatMultiple returns the function that checks that a float value is
+// a multiple of the step, for a float type with the given machine
+// epsilon. The value and the step are rounded to the type when they are
+// parsed, so the error of their quotient grows with the quotient, e.g.
+// 1234.56 / 0.01 in float32 is 123456.0086. The tolerance is scaled
+// accordingly, twice the epsilon relative to the quotient, but at least
+// twice the epsilon.
+func floatMultiple[T numeric](epsilon float64) func(value, step T) bool {
+	return func(value, step T) bool {
+		q := float64(value) / float64(step)
+		tolerance := 2 * epsilon * math.Max(1, math.Abs(q))
+		return math.Abs(q-math.Round(q)) <= tolerance
+	}
+}
+
+// equal reports whether the values are equal. Unordered values are
+// compared deeply, so types that are not comparable with == are
+// supported too.
+func (p parser[T]) equal(a, b T) bool {
+	if p.compare != nil {
+		return p.compare(a, b) == 0
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// seen returns a function that reports whether its argument was passed
+// to it before. The values of basic kinds are kept in a map, the others
+// are compared with equal one by one.
+func (p parser[T]) seen() func(T) bool {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64,
+		reflect.String, reflect.Bool:
+		set := map[any]struct{}{}
+		return func(value T) bool {
+			_, ok := set[value]
+			set[value] = struct{}{}
+			return ok
+		}
+	}
+
+	var values []T
+	return func(value T) bool {
+		for _, v := range values {
+			if p.equal(v, value) {
+				return true
+			}
+		}
+		values = append(values, value)
+		return false
+	}
+}
+
+// str formats the value for an error message.
+func (p parser[T]) str(value T) string {
+	if p.format != nil {
+		return p.format(value)
+	}
+
+	return fmt.Sprint(value)
+}
+
+// spec is the resolved configuration of a query parameter of type T:
+// the default value and the constraints of the value, or of every
+// element for slices.
+type spec[T any] struct {
+	def      T   // the default value of a scalar
+	defs     []T // the default value of a slice
+	hasDef   bool
+	min      T
+	max      T
+	hasMin   bool
+	hasMax   bool
+	oneOf    []T  // valid values, in addition to the range if any
+	noSplit  bool // do not split a single value, see SplitAuto
+	required bool // the parameter must be present and not empty
+	err      error
+
+	duplicates DuplicatePolicy // the policy for a repeated scalar
+	outOfRange RangePolicy     // the policy for values outside the range
+
+	minExclusive bool // the value must be greater than min
+	maxExclusive bool // the value must be less than max
+	step         T    // the value must be a multiple of step
+	hasStep      bool
+
+	minItems  int  // the minimum number of slice items, 0 - any
+	maxItems  int  // the maximum number of slice items, 0 - any
+	maxLength int  // the maximum total length of slice items, 0 - any
+	unique    bool // the slice items must be distinct
+
+	split  SplitMode // which values of a slice are split
+	sep    string    // the separator of slice items, "" - comma
+	quoted bool      // slice items may be quoted, see Quoted
+
+	aliases    map[string]T // the values of alternative spellings
+	ignoreCase bool         // match oneOf and aliases ignoring case
+
+	minRunes  int                 // the minimum length of a value, 0 - any
+	maxRunes  int                 // the maximum length of a value, 0 - any
+	pattern   *regexp.Regexp      // the pattern of a value, or nil
+	charset   func(rune) bool     // reports allowed characters, or nil
+	trim      bool                // trim white space of a value
+	normalize func(string) string // transforms a value, or nil
+
+	limits Limits // the limits of the call, see Limit
+}
+
+// charset returns the function that reports whether the character is
+// listed in chars or belongs to one of the Unicode classes.
+func charset(chars string, tables []*unicode.RangeTable) func(rune) bool {
+	return func(r rune) bool {
+		return strings.ContainsRune(chars, r) || unicode.IsOneOf(tables, r)
+	}
+}
+
+// errSliceOnly is the error of the slice options of a scalar.
+var errSliceOnly = errors.New("MinItems, MaxItems, MaxTotalLength, " +
+	"Unique, Split, Separator and Quoted are supported only for slices")
+
+// sliceOnly reports whether any option of a slice as a whole is set.
+func (s *spec[T]) sliceOnly() bool {
+	return s.minItems != 0 || s.maxItems != 0 || s.maxLength != 0 ||
+		s.unique || s.split != SplitAuto || s.sep != "" || s.quoted
+}
+
+// legacySpec converts the optional values of the variadic numeric
+// functions, see ParseInt: the first one is the default value, the first
+// two are the range (swapped if reversed), the rest are additional
+// valid values.
+func legacySpec[T cmp.Ordered](opt []T) spec[T] {
+	var s spec[T]
+	if len(opt) > 0 {
+		s.def, s.hasDef = opt[0], true
+	}
+
+	if len(opt) > 1 {
+		s.min, s.max = min(opt[0], opt[1]), max(opt[0], opt[1])
+		s.hasMin, s.hasMax = true, true
+	}
+
+	if len(opt) > 2 {
+		s.oneOf = append(make([]T, 0, len(opt)-2), opt[2:]...)
+	}
+
+	return s
+}
+
+// legacySliceSpec converts the optional default value of the variadic
+// slice functions.
+func legacySliceSpec[T any](opt [][]T) spec[T] {
+	var s spec[T]
+	if len(opt) > 0 {
+		s.defs, s.hasDef = opt[0], true
+	}
+
+	return s
+}
+
+// check returns an error if the value violates the constraints. The raw
+// value and the index (-1 for scalars) are used for the error.
+func (s *spec[T]) check(
+	p parser[T],
+	key, raw string,
+	index int,
+	value T,
+) error {
+	for _, v := range s.oneOf {
+		if p.equal(v, value) {
+			return nil
+		}
+	}
+
+	var err *ParamError
+	bounded := s.hasMin || s.hasMax
+	switch {
+	case !bounded && len(s.oneOf) != 0:
+		err = newParamError(ErrNotAllowed, key, raw, s.constraint(p))
+	case bounded && !s.inRange(p, value):
+		err = newParamError(ErrOutOfRange, key, raw, s.constraint(p))
+	case s.hasStep && !p.multiple(value, s.step):
+		err = newParamError(ErrNotMultiple, key, raw,
+			"multiple of "+p.str(s.step))
+	default:
+		return nil
+	}
+
+	err.Index = index
+	return err
+}
+
+// prepare trims and normalizes the raw value, or an element of a slice.
+func (s *spec[T]) prepare(raw string) string {
+	if s.trim {
+		raw = strings.TrimSpace(raw)
+	}
+
+	if s.normalize != nil {
+		raw = s.normalize(raw)
+	}
+
+	return raw
+}
+
+// checkText checks the string constraints of the raw value. The index
+// is the element index for slices or -1 for scalar values.
+func (s *spec[T]) checkText(key, raw string, index int) error {
+	var err *ParamError
+	n := utf8.RuneCountInString(raw)
+	switch {
+	case n < s.minRunes:
+		err = newParamError(ErrTooShort, key, raw, s.lengthConstraint())
+	case s.maxRunes != 0 && n > s.maxRunes:
+		err = newParamError(ErrTooLong, key, raw, s.lengthConstraint())
+	case s.pattern != nil && !s.pattern.MatchString(raw):
+		err = newParamError(ErrPattern, key, raw,
+			"pattern "+s.pattern.String())
+	case s.charset != nil:
+		i := strings.IndexFunc(raw, func(r rune) bool {
+			return !s.charset(r)
+		})
+		if i < 0 {
+			return nil
+		}
+
+		r, _ := utf8.DecodeRuneInString(raw[i:])
+		err = newParamError(ErrCharset, key, raw,
+			fmt.Sprintf("character %q not allowed", r))
+	default:
+		return nil
+	}
+
+	err.Index = index
+	return err
+}
+
+// element checks the string constraints of the raw element of a slice
+// and parses it.
+func (s *spec[T]) element(p parser[T], key, str string, i int) (T, error) {
+	if err := s.checkText(key, str, i); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	v, err := s.parse(p, str)
+	if err != nil {
+		return v, syntaxError(err, key, str, i, kindOf[T]())
+	}
+
+	return v, nil
+}
+
+// lengthConstraint describes the valid length of a value.
+func (s *spec[T]) lengthConstraint() string {
+	switch {
+	case s.maxRunes == 0:
+		return fmt.Sprintf("min length %d", s.minRunes)
+	case s.minRunes == 0:
+		return fmt.Sprintf("max length %d", s.maxRunes)
+	}
+
+	return fmt.Sprintf("length %d-%d", s.minRunes, s.maxRunes)
+}
+
+// parse parses the raw value, or an element of a slice. An alias is
+// replaced with its value, and with ignoreCase a valid value is returned
+// in its canonical spelling.
+func (s *spec[T]) parse(p parser[T], raw string) (T, error) {
+	if v, ok := s.aliases[raw]; ok {
+		return v, nil
+	} else if !s.ignoreCase {
+		return p.parse(raw)
+	}
+
+	for alias, v := range s.aliases {
+		if strings.EqualFold(alias, raw) {
+			return v, nil
+		}
+	}
+
+	for _, v := range s.oneOf {
+		if strings.EqualFold(p.str(v), raw) {
+			return v, nil
+		}
+	}
+
+	if p.fold != nil {
+		if v, ok := p.fold(raw); ok {
+			return v, nil
+		}
+	}
+
+	return p.parse(raw)
+}
+
+// inRange reports whether the value is inside the range.
+func (s *spec[T]) inRange(p parser[T], value T) bool {
+	if s.hasMin {
+		if c := p.compare(value, s.min); c < 0 || c == 0 && s.minExclusive {
+			return false
+		}
+	}
+
+	if s.hasMax {
+		if c := p.compare(value, s.max); c > 0 || c == 0 && s.maxExclusive {
+			return false
+		}
+	}
+
+	return true
+}
+
+// adjustable reports whether the error of check is an out-of-range value
+// that is adjusted by the policy instead of being reported.
+func (s *spec[T]) adjustable(err error) bool {
+	return err != nil && s.outOfRange != RangeError &&
+		errors.Is(err, ErrOutOfRange)
+}
+
+// clamp returns the nearest bound of the range for a value outside it.
+func (s *spec[T]) clamp(p parser[T], value T) T {
+	if s.hasMin && p.compare(value, s.min) < 0 {
+		return s.min
+	} else if s.hasMax && p.compare(value, s.max) > 0 {
+		return s.max
+	}
+
+	return value
+}
+
+// constraint describes the range and the valid values, e.g.
+// "range [1, 100]", "range (0, 1]", "min 0", "greater than 0" or
+// "one of [a b]".
+func (s *spec[T]) constraint(p parser[T]) string {
+	var others []string
+	for _, v := range s.oneOf {
+		others = append(others, p.str(v))
+	}
+
+	var result string
+	switch {
+	case s.hasMin && s.hasMax:
+		left, right := "[", "]"
+		if s.minExclusive {
+			left = "("
+		}
+		if s.maxExclusive {
+			right = ")"
+		}
+		result = "range " + left + p.str(s.min) + ", " + p.str(s.max) + right
+	case s.hasMin && s.minExclusive:
+		result = "greater than " + p.str(s.min)
+	case s.hasMin:
+		result = "min " + p.str(s.min)
+	case s.hasMax && s.maxExclusive:
+		result = "less than " + p.str(s.max)
+	case s.hasMax:
+		result = "max " + p.str(s.max)
+	default:
+		return fmt.Sprintf("one of %v", others)
+	}
+
+	if len(others) != 0 {
+		result += fmt.Sprintf(" or one of %v", others)
+	}
+
+	return result
+}
+
+// validate returns an error if the constraints cannot be applied
+// to the values of the parser.
+func (s *spec[T]) validate(p parser[T]) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	if (s.hasMin || s.hasMax) && p.compare == nil {
+		return fmt.Errorf("min and max are not supported for %s",
+			kindOf[T]())
+	}
+
+	exclusive := s.minExclusive || s.maxExclusive
+	if s.hasMin && s.hasMax {
+		if c := p.compare(s.min, s.max); c > 0 {
+			return errors.New("min is greater than max")
+		} else if c == 0 && exclusive {
+			return errors.New("the exclusive range is empty")
+		}
+	}
+
+	if exclusive && s.outOfRange == RangeClamp {
+		return errors.New("exclusive bounds cannot be clamped")
+	} else if s.hasStep && s.outOfRange == RangeClamp {
+		return errors.New("MultipleOf cannot be combined with RangeClamp")
+	}
+
+	if s.minItems < 0 || s.maxItems < 0 || s.maxLength < 0 {
+		return errors.New("MinItems, MaxItems and MaxTotalLength must " +
+			"not be negative")
+	} else if s.maxItems != 0 && s.minItems > s.maxItems {
+		return errors.New("MinItems is greater than MaxItems")
+	}
+
+	if s.minRunes < 0 || s.maxRunes < 0 {
+		return errors.New("MinLength and MaxLength must not be negative")
+	} else if s.maxRunes != 0 && s.minRunes > s.maxRunes {
+		return errors.New("MinLength is greater than MaxLength")
+	}
+
+	if s.quoted && strings.Contains(s.sep, `"`) {
+		return errors.New("the separator of quoted items must not " +
+			"contain a quote")
+	}
+
+	if s.hasStep {
+		var zero T
+		if p.multiple == nil {
+			return fmt.Errorf("MultipleOf is not supported for %s",
+				kindOf[T]())
+		} else if p.compare(s.step, zero) <= 0 {
+			return errors.New("MultipleOf must be positive")
+		}
+	}
+
+	return nil
+}
+
+// errQuote is the error of a malformed quoted slice item.
+var errQuote = errors.New("malformed quote")
+
+// splitMode returns the split mode for the values of a slice, SplitAuto
+// is resolved to SplitEach or SplitNone.
+func (s *spec[T]) splitMode(p parser[T], data []string) SplitMode {
+	if s.split != SplitAuto {
+		return s.split
+	} else if len(data) == 1 && !s.noSplit || p.plain {
+		return SplitEach
+	}
+
+	return SplitNone
+}
+
+// items splits the values of a slice into the raw items, see SplitMode.
+// If an item is not quoted properly, its index is returned as the second
+// value, otherwise -1.
+func (s *spec[T]) items(p parser[T], data []string) ([]string, int) {
+	if s.splitMode(p, data) == SplitNone {
+		return data, -1
+	}
+
+	sep := cmp.Or(s.sep, ",")
+	if !s.quoted && !slices.ContainsFunc(data, func(str string) bool {
+		return strings.Contains(str, sep)
+	}) {
+		return data, -1 // nothing to split, e.g. "?ids=1&ids=2"
+	}
+
+	items := make([]string, 0, len(data))
+	for _, str := range data {
+		if !s.quoted {
+			items = append(items, strings.Split(str, sep)...)
+			continue
+		}
+
+		parts, ok := splitQuoted(str, sep)
+		items = append(items, parts...)
+		if !ok {
+			return items, len(items) - 1
+		}
+	}
+
+	return items, -1
+}
+
+// splitQuoted splits the string at the separator outside of double
+// quotes and unquotes the quoted items. If a quote is malformed, the
+// last item holds the rest of the string and false is returned.
+func splitQuoted(str, sep string) ([]string, bool) {
+	var items []string
+	for {
+		if !strings.HasPrefix(str, `"`) {
+			item, rest, ok := strings.Cut(str, sep)
+			items = append(items, item)
+			if !ok {
+				return items, true
+			}
+			str = rest
+			continue
+		}
+
+		// A quoted item ends at a single quote, "" is an escaped quote.
+		var b strings.Builder
+		i := 1
+		for {
+			j := strings.IndexByte(str[i:], '"')
+			if j < 0 {
+				return append(items, str), false
+			}
+
+			b.WriteString(str[i : i+j])
+			i += j + 1
+			if !strings.HasPrefix(str[i:], `"`) {
+				break
+			}
+			b.WriteByte('"')
+			i++
+		}
+
+		if rest := str[i:]; rest == "" {
+			return append(items, b.String()), true
+		} else if !strings.HasPrefix(rest, sep) {
+			return append(items, str), false
+		}
+
+		items = append(items, b.String())
+		str = str[i+len(sep):]
+	}
+}
+
+// checkItems checks the number and the total length of the raw items
+// of a slice.
+func (s *spec[T]) checkItems(key string, items []string) error {
+	if n := len(items); n < s.minItems || s.maxItems != 0 && n > s.maxItems {
+		return newParamError(ErrItemCount, key, strings.Join(items, ","),
+			s.itemsConstraint())
+	}
+
+	if s.maxLength == 0 {
+		return nil
+	}
+
+	total := 0
+	for _, str := range items {
+		total += utf8.RuneCountInString(str)
+	}
+
+	if total > s.maxLength {
+		return newParamError(ErrTooLong, key, strings.Join(items, ","),
+			fmt.Sprintf("max total length %d", s.maxLength))
+	}
+
+	return nil
+}
+
+// itemsConstraint describes the valid number of slice items.
+func (s *spec[T]) itemsConstraint() string {
+	switch {
+	case s.maxItems == 0:
+		return fmt.Sprintf("min %d items", s.minItems)
+	case s.minItems == 0:
+		return fmt.Sprintf("max %d items", s.maxItems)
+	}
+
+	return fmt.Sprintf("%d-%d items", s.minItems, s.maxItems)
+}
+
+// scalar parses a query parameter of the query.
+func scalar[T any](q *Query, key string, s spec[T], p parser[T]) *Result[T] {
+	result := &Result[T]{
+		Key:      key,
+		Value:    s.def,
+		Default:  s.def,
+		Min:      s.min,
+		Max:      s.max,
+		Others:   s.oneOf,
+		Contains: true,
+	}
+	data, ok := q.lookup(key)
+	result.Count = len(data)
+
+	// Check the size before the value is prepared.
+	if err := q.checkSize(key, data, q.limitsFor(s.limits)); err != nil {
+		result.Empty = len(data) == 0 || data[0] == ""
+		result.Contains = ok
+		result.Error = err
+		return result
+	}
+
+	raw, dupErr := q.value(key, data, s.duplicates)
+	raw = s.prepare(raw)
+
+	// Check if the query parameter is empty or missing.
+	if !ok {
+		result.Empty = true
+		result.Contains = false
+	} else if raw == "" {
+		result.Empty = true
+	}
+
+	if err := s.validate(p); err != nil {
+		result.Error = invalidOption(key, err)
+		return result
+	} else if dupErr != nil {
+		result.Error = dupErr
+		return result
+	} else if result.Empty {
+		if s.required {
+			result.Error = requiredError(key, result.Contains)
+		}
+		return result
+	}
+
+	if err := s.checkText(key, raw, -1); err != nil {
+		result.Error = err
+		return result
+	}
+
+	value, err := s.parse(p, raw)
+	if err != nil {
+		result.Error = syntaxError(err, key, raw, -1, kindOf[T]())
+		return result
+	}
+
+	if err := s.check(p, key, raw, -1, value); s.adjustable(err) {
+		result.Adjusted = true
+		if s.outOfRange == RangeClamp {
+			result.Value = s.clamp(p, value)
+		}
+		return result
+	} else if err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Value = value
+	return result
+}
+
+// slice parses a slice query parameter of the query. The constraints
+// of the spec are applied to every element.
+func slice[T any](q *Query, key string, s spec[T], p parser[T]) *Result[[]T] {
+	result := &Result[[]T]{Key: key, Default: []T{}, Contains: true}
+	if s.hasDef {
+		result.Default = s.defs
+	}
+	result.Value = result.Default
+	data, ok := q.lookup(key)
+	result.Count = len(data)
+
+	// Check the size before the values are split.
+	limits := q.limitsFor(s.limits)
+	if err := q.checkSize(key, data, limits); err != nil {
+		result.Empty = len(data) == 0 || data[0] == ""
+		result.Contains = ok
+		result.Error = err
+		return result
+	}
+
+	// Check if the query parameter is empty or missing.
+	if !ok {
+		result.Empty = true
+		result.Contains = false
+	} else if len(data) == 0 || s.prepare(data[0]) == "" {
+		result.Empty = true
+	}
+
+	if err := s.validate(p); err != nil {
+		result.Error = invalidOption(key, err)
+		return result
+	} else if result.Empty {
+		if s.required {
+			result.Error = requiredError(key, result.Contains)
+		}
+		return result
+	}
+
+	// An array can be specified as a single string "?ids=1,2,3" or
+	// as multiple values "?ids=1&ids=2&ids=3".
+	if err := s.checkElements(p, key, data, limits); err != nil {
+		result.Error = err
+		return result
+	}
+
+	items, bad := s.items(p, data)
+	if bad >= 0 {
+		result.Error = syntaxError(errQuote, key, items[bad], bad,
+			kindOf[T]())
+		return result
+	} else if s.trim || s.normalize != nil {
+		prepared := make([]string, len(items)) // items may be the data
+		for i, str := range items {
+			prepared[i] = s.prepare(str)
+		}
+		items = prepared
+	}
+
+	if err := s.checkItems(key, items); err != nil {
+		result.Error = err
+		return result
+	}
+
+	var seen func(T) bool
+	if s.unique {
+		seen = p.seen()
+	}
+
+	value := make([]T, 0, len(items))
+	for i, str := range items {
+		v, err := s.element(p, key, str, i)
+		if err == nil {
+			if err = s.check(p, key, str, i, v); s.adjustable(err) {
+				result.Adjusted = true
+				if s.outOfRange == RangeDefault {
+					return result
+				}
+				v, err = s.clamp(p, v), nil
+			}
+		}
+
+		if err == nil && s.unique && seen(v) {
+			e := newParamError(ErrNotUnique, key, str, "unique items")
+			e.Index = i
+			err = e
+		}
+
+		if err != nil {
+			result.Error = err
+			result.Value = []T{} // not nil
+			return result
+		}
+		value = append(value, v)
+	}
+
+	result.Value = value
+	return result
+}